@@ -0,0 +1,71 @@
+package snap_validator
+
+import (
+	"fmt"
+	"github.com/apelweb15/snap-validator/internal/models"
+	"strings"
+)
+
+// CompileIssue is a problem found in the snapValidator tags of a type.
+type CompileIssue = models.CompileIssue
+
+type CompileIssueKind = models.CompileIssueKind
+
+const (
+	IssueUnknownRule      = models.IssueUnknownRule
+	IssueMalformedParam   = models.IssueMalformedParam
+	IssueIncompatibleKind = models.IssueIncompatibleKind
+	IssueSuspiciousTag    = models.IssueSuspiciousTag
+)
+
+// CompileReport lists every issue found by Compile. It is returned as the
+// error of Compile when at least one issue was found.
+type CompileReport struct {
+	Type   string
+	Issues []CompileIssue
+}
+
+func (r *CompileReport) Error() string {
+	lines := make([]string, 0, len(r.Issues)+1)
+	lines = append(lines, fmt.Sprintf("snap validator: %d issue(s) in %s", len(r.Issues), r.Type))
+	for _, issue := range r.Issues {
+		lines = append(lines, "\t"+issue.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Compile checks the snapValidator tags of the default validator.
+func Compile(data interface{}) error {
+	return snapValidator.Compile(data)
+}
+
+// MustRegister is Compile on the default validator that panics on issues.
+func MustRegister(data ...interface{}) {
+	snapValidator.MustRegister(data...)
+}
+
+// Compile walks the type of data (e.g. Request{} or &Request{}) once,
+// caching its validation plan, and returns a *CompileReport listing unknown
+// rules, malformed rule parameters, rules applied to fields of the wrong
+// kind and misspelled snapValidator tags. It returns nil when the type is
+// clean. Custom rules must be registered before calling Compile.
+func (v *SnapValidator) Compile(data interface{}) error {
+	issues := v.validator.Compile(data)
+	if len(issues) == 0 {
+		return nil
+	}
+	return &CompileReport{
+		Type:   fmt.Sprintf("%T", data),
+		Issues: issues,
+	}
+}
+
+// MustRegister compiles each value and panics if any has issues. It is meant
+// for package init or main, so tag mistakes stop the service at startup.
+func (v *SnapValidator) MustRegister(data ...interface{}) {
+	for _, d := range data {
+		if err := v.Compile(d); err != nil {
+			panic(err)
+		}
+	}
+}
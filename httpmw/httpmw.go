@@ -0,0 +1,146 @@
+// Package httpmw decodes and validates SNAP requests for net/http handlers.
+//
+//	http.Handle("/v1.0/transfer-va/payment", httpmw.New(
+//		snap_validator_services.VirtualAccountPayment,
+//		func(w http.ResponseWriter, r *http.Request, req *PaymentRequest) {
+//			...
+//		},
+//	))
+//
+// Requests that cannot be decoded or fail validation are answered with the
+// SNAP error body and HTTP status, e.g. 400 {"responseCode":"4002502",...}.
+package httpmw
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	snap_validator "github.com/apelweb15/snap-validator"
+	"github.com/apelweb15/snap-validator/snap_validator_errors"
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// DefaultMaxBodyBytes limits the request body size unless WithMaxBodyBytes
+// is used.
+const DefaultMaxBodyBytes = 1 << 20
+
+// HandlerFunc receives a decoded and validated request.
+type HandlerFunc[T any] func(w http.ResponseWriter, r *http.Request, req *T)
+
+// ErrorHandler writes the response for a request that failed decoding or
+// validation.
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+type config struct {
+	validator    *snap_validator.SnapValidator
+	maxBodyBytes int64
+	errorHandler ErrorHandler
+}
+
+// Option configures a Handler created with New.
+type Option func(*config)
+
+// WithValidator validates with v instead of the default validator, e.g. one
+// with custom rules or messages.
+func WithValidator(v *snap_validator.SnapValidator) Option {
+	return func(c *config) {
+		c.validator = v
+	}
+}
+
+// WithMaxBodyBytes limits the request body to n bytes.
+func WithMaxBodyBytes(n int64) Option {
+	return func(c *config) {
+		c.maxBodyBytes = n
+	}
+}
+
+// WithErrorHandler replaces the default error response, e.g. to log the
+// error before writing it.
+func WithErrorHandler(handler ErrorHandler) Option {
+	return func(c *config) {
+		c.errorHandler = handler
+	}
+}
+
+// Handler is an http.Handler that decodes the JSON body into T, validates it
+// for its service code and passes it to the next handler.
+type Handler[T any] struct {
+	serviceCode snap_validator_services.ServiceCode
+	next        HandlerFunc[T]
+	config      config
+}
+
+// New returns a Handler for serviceCode. It panics if serviceCode is
+// malformed.
+func New[T any](serviceCode snap_validator_services.ServiceCode, next HandlerFunc[T], opts ...Option) *Handler[T] {
+	if err := serviceCode.Validate(); err != nil {
+		panic("httpmw: " + err.Error())
+	}
+	h := &Handler[T]{
+		serviceCode: serviceCode,
+		next:        next,
+		config:      config{maxBodyBytes: DefaultMaxBodyBytes},
+	}
+	for _, opt := range opts {
+		opt(&h.config)
+	}
+	if h.config.validator == nil {
+		h.config.validator = snap_validator.New()
+	}
+	if h.config.errorHandler == nil {
+		h.config.errorHandler = h.writeError
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler. The body stays readable by the next
+// handler, e.g. to verify its signature.
+func (h *Handler[T]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.config.maxBodyBytes))
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		h.config.errorHandler(w, r, h.newError(r, "40000", "", "body_too_large", []string{strconv.FormatInt(maxBytesError.Limit, 10)}, err))
+		return
+	}
+	if err != nil {
+		h.config.errorHandler(w, r, h.newError(r, "40000", "", "invalid_body", nil, err))
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	req := new(T)
+	if err := json.Unmarshal(body, req); err != nil {
+		h.config.errorHandler(w, r, h.decodeError(r, err))
+		return
+	}
+	if err := h.config.validator.ValidateStructContext(r.Context(), req, h.serviceCode); err != nil {
+		h.config.errorHandler(w, r, err)
+		return
+	}
+	h.next(w, r, req)
+}
+
+func (h *Handler[T]) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	_ = snap_validator_errors.ResponseFromError(err, string(h.serviceCode)).Write(w)
+}
+
+// decodeError reports a field with the wrong JSON type as 40001 and any
+// other decoding failure as 40000.
+func (h *Handler[T]) decodeError(r *http.Request, err error) error {
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) && typeError.Field != "" {
+		return h.newError(r, "40001", typeError.Field, "json_type", []string{typeError.Value}, err)
+	}
+	return h.newError(r, "40000", "", "invalid_body", nil, err)
+}
+
+// newError builds a service coded error in the locale of the request
+// context, with Detail taken from the catalog entry of rule. A body over the
+// size limit is reported as 40000 Bad Request with the body_too_large detail.
+func (h *Handler[T]) newError(r *http.Request, code string, label string, rule string, params []string, cause error) error {
+	return snap_validator_errors.NewErrorSnapLocale(r.Context(), code, label, string(h.serviceCode), rule, params, cause)
+}
@@ -0,0 +1,104 @@
+package httpmw
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/apelweb15/snap-validator/snap_validator_errors"
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type inquiryRequest struct {
+	PartnerServiceId string `json:"partnerServiceId" snapValidator:"required|max_length:8"`
+	CustomerNo       string `json:"customerNo" snapValidator:"required|numeric|max_length:20"`
+	InquiryRequestId string `json:"inquiryRequestId" snapValidator:"required|max_length:128"`
+}
+
+func serve(t *testing.T, body string) (*httptest.ResponseRecorder, *inquiryRequest) {
+	var received *inquiryRequest
+	handler := New(snap_validator_services.VirtualAccountInquiry, func(w http.ResponseWriter, r *http.Request, req *inquiryRequest) {
+		raw, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, body, string(raw))
+		received = req
+		w.WriteHeader(http.StatusOK)
+	})
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/v1.0/transfer-va/inquiry", strings.NewReader(body)))
+	return recorder, received
+}
+
+func response(t *testing.T, recorder *httptest.ResponseRecorder) snap_validator_errors.Response {
+	var res snap_validator_errors.Response
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	return res
+}
+
+func TestHandler(t *testing.T) {
+	recorder, req := serve(t, `{"partnerServiceId":"   12345","customerNo":"123","inquiryRequestId":"abc"}`)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "123", req.CustomerNo)
+
+	recorder, req = serve(t, `{"partnerServiceId":"   12345","customerNo":"123"}`)
+	assert.Nil(t, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	res := response(t, recorder)
+	assert.Equal(t, "4002402", res.ResponseCode)
+	assert.Equal(t, "Missing Mandatory Field inquiryRequestId", res.ResponseMessage)
+
+	recorder, _ = serve(t, `{"partnerServiceId":"   12345","customerNo":123,"inquiryRequestId":"abc"}`)
+	res = response(t, recorder)
+	assert.Equal(t, "4002401", res.ResponseCode)
+	assert.Equal(t, "Invalid Field Format customerNo", res.ResponseMessage)
+
+	recorder, _ = serve(t, `{"partnerServiceId":`)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	res = response(t, recorder)
+	assert.Equal(t, "4002400", res.ResponseCode)
+	assert.Equal(t, "Bad Request", res.ResponseMessage)
+}
+
+func TestHandlerLocale(t *testing.T) {
+	handler := New(snap_validator_services.VirtualAccountInquiry, func(w http.ResponseWriter, r *http.Request, req *inquiryRequest) {
+		t.Fatal("next handler called")
+	})
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"customerNo":123}`))
+	r = r.WithContext(snap_validator_errors.ContextWithLocale(r.Context(), snap_validator_errors.LocaleIndonesian))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, r)
+	res := response(t, recorder)
+	assert.Equal(t, "4002401", res.ResponseCode)
+	assert.Equal(t, "Format Field Tidak Valid customerNo", res.ResponseMessage)
+}
+
+func TestMaxBodyBytes(t *testing.T) {
+	handler := New(snap_validator_services.VirtualAccountInquiry, func(w http.ResponseWriter, r *http.Request, req *inquiryRequest) {
+		t.Fatal("next handler called")
+	}, WithMaxBodyBytes(10))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"customerNo":"123"}`)))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	res := response(t, recorder)
+	assert.Equal(t, "4002400", res.ResponseCode)
+	assert.Equal(t, "Bad Request", res.ResponseMessage)
+
+	var errorRes *snap_validator_errors.ErrorValidation
+	handler = New(snap_validator_services.VirtualAccountInquiry, func(w http.ResponseWriter, r *http.Request, req *inquiryRequest) {
+		t.Fatal("next handler called")
+	}, WithMaxBodyBytes(10), WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+		assert.True(t, errors.As(err, &errorRes))
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"customerNo":"123"}`)))
+	assert.Equal(t, "body_too_large", errorRes.Rule)
+	assert.Equal(t, "body is larger than 10 bytes", errorRes.Detail)
+
+	assert.Panics(t, func() {
+		New[inquiryRequest]("2", nil)
+	})
+}
@@ -5,6 +5,18 @@ import "reflect"
 type CustomValidator struct {
 	FieldType  reflect.StructField
 	FieldValue reflect.Value
+	// Param is the parameter of the rule being evaluated, e.g. "8" for max_length:8.
+	Param string
+	// Struct is the struct value holding the field, used by rules that
+	// reference sibling fields.
+	Struct reflect.Value
+	// Element is set when FieldValue is an element of the field reached
+	// through dive, or a key or value of a map field; ElementKey is appended
+	// to the label (e.g. tags.3, additionalInfo.deviceId) and ElementIndex is
+	// the slice index, or -1 for maps.
+	Element      bool
+	ElementKey   string
+	ElementIndex int
 }
 
 type ValidatorProperty struct {
@@ -14,4 +26,57 @@ type ValidatorProperty struct {
 	FieldType  reflect.StructField
 	FieldValue reflect.Value
 	IdxArray   int
+	// Map is set for values reached through a map field; MapKey is the key.
+	Map    bool
+	MapKey string
+}
+
+// RuleContext is passed to custom rules registered on the validator.
+type RuleContext struct {
+	// Value is the field value with pointers and interfaces dereferenced, or
+	// nil when the field is a nil pointer or interface.
+	Value interface{}
+	// FieldValue is the reflect form of Value; it is the zero reflect.Value
+	// when Value is nil.
+	FieldValue reflect.Value
+	Field      reflect.StructField
+	// Param is the raw rule parameter, e.g. "12345" for va_prefix:12345.
+	Param string
+	// Params is Param without surrounding brackets, split on commas.
+	Params []string
+	// Path is the dotted JSON path of the field, e.g. billDetails.0.billCode.
+	Path        string
+	Parents     []ValidatorProperty
+	ServiceCode string
+}
+
+// RuleFunc validates a single field. Returning an *ErrorValidation with a
+// Code selects the SNAP case code (e.g. "40002"); any other error is
+// reported as 40001 Invalid Field Format.
+type RuleFunc func(ctx RuleContext) error
+
+type CompileIssueKind string
+
+const (
+	IssueUnknownRule      CompileIssueKind = "unknown_rule"
+	IssueMalformedParam   CompileIssueKind = "malformed_param"
+	IssueIncompatibleKind CompileIssueKind = "incompatible_kind"
+	IssueSuspiciousTag    CompileIssueKind = "suspicious_tag"
+)
+
+// CompileIssue is a problem found in the snapValidator tags of a type.
+type CompileIssue struct {
+	// Type is the struct type declaring the field, e.g. snap_validator.BillDetail.
+	Type  string
+	Field string
+	// Path is the JSON path from the compiled root with "*" for array
+	// elements, e.g. billDetails.*.billCode.
+	Path    string
+	Rule    string
+	Kind    CompileIssueKind
+	Message string
+}
+
+func (i CompileIssue) String() string {
+	return i.Path + ": " + i.Message
 }
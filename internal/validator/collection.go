@@ -0,0 +1,92 @@
+package validator
+
+import (
+	"github.com/apelweb15/snap-validator/internal/models"
+	"github.com/apelweb15/snap-validator/snap_validator_errors"
+	"reflect"
+)
+
+// itemCount returns the number of items of a slice, array or map value.
+func itemCount(value reflect.Value) (int, bool) {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return value.Len(), true
+	}
+	return 0, false
+}
+
+func (v validatorImpl[T]) ValidateMinItems(count int, data interface{}) error {
+	value := reflect.ValueOf(data)
+	if items, ok := itemCount(value); ok && items < count {
+		return &snap_validator_errors.ErrorValidation{
+			Code:    "40001",
+			Message: "Invalid field format",
+		}
+	}
+	return nil
+}
+
+func (v validatorImpl[T]) validateMinItems(count int, customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
+	errorCode := "40001"
+	err := v.ValidateMinItems(count, interfaceOf(customValidator.FieldValue))
+	if err != nil {
+		return v.parsingError(customValidator, errorCode, "min_items", parentProperty...)
+	}
+	return nil
+}
+
+func (v validatorImpl[T]) ValidateMaxItems(count int, data interface{}) error {
+	value := reflect.ValueOf(data)
+	if items, ok := itemCount(value); ok && items > count {
+		return &snap_validator_errors.ErrorValidation{
+			Code:    "40001",
+			Message: "Invalid field format",
+		}
+	}
+	return nil
+}
+
+func (v validatorImpl[T]) validateMaxItems(count int, customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
+	errorCode := "40001"
+	err := v.ValidateMaxItems(count, interfaceOf(customValidator.FieldValue))
+	if err != nil {
+		return v.parsingError(customValidator, errorCode, "max_items", parentProperty...)
+	}
+	return nil
+}
+
+func (v validatorImpl[T]) ValidateUnique(data interface{}) error {
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil
+	}
+	duplicate := false
+	seen := make(map[interface{}]bool, value.Len())
+	for i := 0; i < value.Len() && !duplicate; i++ {
+		item := value.Index(i)
+		if item.Comparable() {
+			duplicate = seen[item.Interface()]
+			seen[item.Interface()] = true
+			continue
+		}
+		for j := 0; j < i && !duplicate; j++ {
+			duplicate = reflect.DeepEqual(item.Interface(), value.Index(j).Interface())
+		}
+	}
+	if duplicate {
+		return &snap_validator_errors.ErrorValidation{
+			Code:    "40001",
+			Message: "Invalid field format",
+		}
+	}
+	return nil
+}
+
+func (v validatorImpl[T]) validateUnique(customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
+	errorCode := "40001"
+	err := v.ValidateUnique(interfaceOf(customValidator.FieldValue))
+	if err != nil {
+		return v.parsingError(customValidator, errorCode, "unique", parentProperty...)
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package validator
+
+import (
+	"fmt"
+	"github.com/apelweb15/snap-validator/internal/models"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Compile walks the type of data once, caches the plan of every struct it
+// reaches and reports tag problems that would otherwise be silently ignored
+// or only surface as a 500xx00 General Error at request time.
+func (v validatorImpl[T]) Compile(data interface{}) []models.CompileIssue {
+	reflectType := reflect.TypeOf(data)
+	if reflectType == nil {
+		return nil
+	}
+	compiler := &compiler{rules: v.rules, visited: map[reflect.Type]bool{}, root: elemType(reflectType)}
+	compiler.walk(reflectType, "")
+	return compiler.issues
+}
+
+type compiler struct {
+	root    reflect.Type
+	rules   *ruleRegistry
+	visited map[reflect.Type]bool
+	issues  []models.CompileIssue
+}
+
+func (c *compiler) walk(reflectType reflect.Type, path string) {
+	reflectType = elemType(reflectType)
+	if reflectType.Kind() != reflect.Struct || c.visited[reflectType] {
+		return
+	}
+	c.visited[reflectType] = true
+
+	for _, field := range planFor(reflectType).fields {
+		fieldPath := jsonName(field.field.Name, field.jsonName)
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+		c.checkTags(reflectType, field, fieldPath)
+		for _, rule := range field.rules {
+			c.checkRule(reflectType, field, fieldPath, rule, field.field.Type)
+		}
+
+		fieldType := elemType(field.field.Type)
+		if field.dive || len(field.keyRules) > 0 {
+			c.checkModifiers(reflectType, field, fieldPath, fieldType)
+		}
+		switch fieldType.Kind() {
+		case reflect.Struct:
+			c.walk(fieldType, fieldPath)
+		case reflect.Slice, reflect.Array, reflect.Map:
+			c.walk(fieldType.Elem(), fieldPath+".*")
+		}
+	}
+}
+
+// checkModifiers checks the rules after dive, keys and values against the
+// element, key and value types of the field.
+func (c *compiler) checkModifiers(structType reflect.Type, field fieldPlan, path string, fieldType reflect.Type) {
+	issue := models.CompileIssue{
+		Type:  structType.String(),
+		Field: field.field.Name,
+		Path:  path,
+		Kind:  models.IssueIncompatibleKind,
+	}
+	switch {
+	case fieldType.Kind() == reflect.Interface:
+		return
+	case len(field.keyRules) > 0 && fieldType.Kind() != reflect.Map:
+		issue.Rule = "keys"
+		issue.Message = fmt.Sprintf("keys only applies to maps, field is %s", field.field.Type)
+		c.issues = append(c.issues, issue)
+	case fieldType.Kind() != reflect.Slice && fieldType.Kind() != reflect.Array && fieldType.Kind() != reflect.Map:
+		issue.Rule = "dive"
+		issue.Message = fmt.Sprintf("dive only applies to slices, arrays and maps, field is %s", field.field.Type)
+		c.issues = append(c.issues, issue)
+	default:
+		for _, rule := range field.elemRules {
+			c.checkRule(structType, field, path+".*", rule, fieldType.Elem())
+		}
+		for _, rule := range field.keyRules {
+			c.checkRule(structType, field, path+".*", rule, fieldType.Key())
+		}
+	}
+}
+
+// checkRule checks one rule of field; valueType is the type the rule is
+// applied to, which is the element type for rules after dive.
+func (c *compiler) checkRule(structType reflect.Type, field fieldPlan, path string, rule rulePlan, valueType reflect.Type) {
+	issue := models.CompileIssue{
+		Type:  structType.String(),
+		Field: field.field.Name,
+		Path:  path,
+		Rule:  rule.name,
+	}
+	spec, builtin := builtinRules[rule.name]
+	if !builtin {
+		if _, ok := c.rules.get(rule.name); !ok {
+			issue.Kind = models.IssueUnknownRule
+			issue.Message = fmt.Sprintf("unknown rule %q", rule.name)
+			c.issues = append(c.issues, issue)
+		}
+		return
+	}
+
+	switch {
+	case rule.err != nil:
+		issue.Kind = models.IssueMalformedParam
+		issue.Message = fmt.Sprintf("%s expects an integer parameter, got %q", rule.name, rule.param)
+	case spec.param == paramInt && rule.length < 0:
+		issue.Kind = models.IssueMalformedParam
+		issue.Message = fmt.Sprintf("%s expects a non-negative parameter, got %q", rule.name, rule.param)
+	case spec.param == paramList && len(rule.params) == 0:
+		issue.Kind = models.IssueMalformedParam
+		issue.Message = fmt.Sprintf("%s expects a list parameter such as %s:[A,B]", rule.name, rule.name)
+	case spec.param == paramFieldValues && len(rule.params) < 2:
+		issue.Kind = models.IssueMalformedParam
+		issue.Message = fmt.Sprintf("%s expects a field and at least one value, e.g. %s:field,value", rule.name, rule.name)
+	case spec.param == paramFields && len(rule.params) == 0:
+		issue.Kind = models.IssueMalformedParam
+		issue.Message = fmt.Sprintf("%s expects at least one field", rule.name)
+	case (spec.param == paramFieldValues || spec.param == paramFields) && c.unknownSibling(structType, spec, rule) != "":
+		issue.Kind = models.IssueMalformedParam
+		issue.Message = fmt.Sprintf("%s references unknown field %q", rule.name, c.unknownSibling(structType, spec, rule))
+	case spec.param == paramPath && rule.param == "":
+		issue.Kind = models.IssueMalformedParam
+		issue.Message = fmt.Sprintf("%s expects a field path", rule.name)
+	case spec.param == paramPath && !c.pathExists(structType, rule.param):
+		issue.Kind = models.IssueMalformedParam
+		issue.Message = fmt.Sprintf("%s references unknown field %q", rule.name, rule.param)
+	case spec.param == paramNone && rule.param != "":
+		issue.Kind = models.IssueMalformedParam
+		issue.Message = fmt.Sprintf("%s does not take a parameter, got %q", rule.name, rule.param)
+	case spec.stringOnly && !kindAccepts(valueType, reflect.String):
+		issue.Kind = models.IssueIncompatibleKind
+		issue.Message = fmt.Sprintf("%s only applies to strings, field is %s", rule.name, valueType)
+	case spec.mapOnly && !kindAccepts(valueType, reflect.Map):
+		issue.Kind = models.IssueIncompatibleKind
+		issue.Message = fmt.Sprintf("%s only applies to maps, field is %s", rule.name, valueType)
+	case spec.collectionOnly && !kindAccepts(valueType, reflect.Slice, reflect.Array, reflect.Map):
+		issue.Kind = models.IssueIncompatibleKind
+		issue.Message = fmt.Sprintf("%s only applies to slices, arrays and maps, field is %s", rule.name, valueType)
+	case spec.orderedOnly && !orderable(valueType):
+		issue.Kind = models.IssueIncompatibleKind
+		issue.Message = fmt.Sprintf("%s only applies to strings, numbers and amounts, field is %s", rule.name, valueType)
+	default:
+		return
+	}
+	c.issues = append(c.issues, issue)
+}
+
+// unknownSibling returns the first field referenced by rule that does not
+// exist in structType.
+func (c *compiler) unknownSibling(structType reflect.Type, spec ruleSpec, rule rulePlan) string {
+	fields := rule.params
+	if spec.param == paramFieldValues {
+		fields = fields[:1]
+	}
+	plan := planFor(structType)
+	for _, name := range fields {
+		if _, ok := plan.sibling(name); !ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// pathExists resolves a field path against the types, the same way
+// resolvePath does against values.
+func (c *compiler) pathExists(structType reflect.Type, path string) bool {
+	current := structType
+	if rest, ok := strings.CutPrefix(path, "$."); ok {
+		current, path = c.root, rest
+	}
+	for _, segment := range strings.Split(path, ".") {
+		current = elemType(current)
+		switch current.Kind() {
+		case reflect.Struct:
+			field, ok := planFor(current).sibling(segment)
+			if !ok {
+				return false
+			}
+			current = field.field.Type
+		case reflect.Slice, reflect.Array:
+			if _, err := strconv.Atoi(segment); err != nil {
+				return false
+			}
+			current = current.Elem()
+		case reflect.Interface:
+			return true
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// checkTags reports struct tag keys that look like a misspelled snapValidator
+// tag, such as validateSnap or snapvalidator.
+func (c *compiler) checkTags(structType reflect.Type, field fieldPlan, path string) {
+	for _, key := range tagKeys(field.field.Tag) {
+		if key == "snapValidator" || !suspiciousTag(key) {
+			continue
+		}
+		c.issues = append(c.issues, models.CompileIssue{
+			Type:    structType.String(),
+			Field:   field.field.Name,
+			Path:    path,
+			Kind:    models.IssueSuspiciousTag,
+			Message: fmt.Sprintf("tag %q is ignored, did you mean \"snapValidator\"?", key),
+		})
+	}
+}
+
+// elemType strips pointers so *T and T are checked alike.
+func elemType(reflectType reflect.Type) reflect.Type {
+	for reflectType.Kind() == reflect.Ptr {
+		reflectType = reflectType.Elem()
+	}
+	return reflectType
+}
+
+// kindAccepts reports whether a field of reflectType can hold a value of
+// kind at run time. Interfaces are accepted since their content is dynamic.
+func kindAccepts(reflectType reflect.Type, kinds ...reflect.Kind) bool {
+	reflectType = elemType(reflectType)
+	if reflectType.Kind() == reflect.Interface {
+		return true
+	}
+	for _, kind := range kinds {
+		if reflectType.Kind() == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// orderable reports whether compareValues can order values of reflectType.
+func orderable(reflectType reflect.Type) bool {
+	if _, ok := amountValueField(elemType(reflectType)); ok {
+		return true
+	}
+	return kindAccepts(reflectType, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64)
+}
+
+func suspiciousTag(key string) bool {
+	normalized := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	if strings.Contains(normalized, "snap") && strings.Contains(normalized, "valid") {
+		return true
+	}
+	return levenshtein(normalized, "snapvalidator") <= 2
+}
+
+// tagKeys returns the keys of a conventional `key:"value" key2:"value"` tag.
+func tagKeys(tag reflect.StructTag) []string {
+	var keys []string
+	s := string(tag)
+	for s != "" {
+		s = strings.TrimLeft(s, " ")
+		i := strings.Index(s, ":\"")
+		if i <= 0 {
+			break
+		}
+		keys = append(keys, s[:i])
+		s = s[i+2:]
+		// skip the quoted value, honouring escaped quotes
+		for j := 0; j < len(s); j++ {
+			if s[j] == '\\' {
+				j++
+				continue
+			}
+			if s[j] == '"' {
+				s = s[j+1:]
+				break
+			}
+		}
+	}
+	return keys
+}
+
+func levenshtein(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,84 @@
+package validator
+
+import (
+	"fmt"
+	"github.com/apelweb15/snap-validator/internal/models"
+	"reflect"
+)
+
+// siblingValue returns the dereferenced value of the sibling field name of
+// the struct holding customValidator's field. ok is false when the struct has
+// no such field.
+func siblingValue(customValidator models.CustomValidator, name string) (reflect.Value, bool) {
+	if !customValidator.Struct.IsValid() {
+		return reflect.Value{}, false
+	}
+	field, ok := planFor(customValidator.Struct.Type()).sibling(name)
+	if !ok {
+		return reflect.Value{}, false
+	}
+	return indirect(customValidator.Struct.Field(field.index)), true
+}
+
+// valueString formats a dereferenced value for comparison with a rule
+// parameter. Absent values format as "".
+func valueString(value reflect.Value) string {
+	if !value.IsValid() {
+		return ""
+	}
+	if value.Kind() == reflect.String {
+		return value.String()
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+func (v validatorImpl[T]) siblingPresent(customValidator models.CustomValidator, name string) bool {
+	value, ok := siblingValue(customValidator, name)
+	return ok && v.ValidateRequired(interfaceOf(value)) == nil
+}
+
+func (v validatorImpl[T]) validateRequiredIf(params []string, customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
+	if len(params) < 2 {
+		return nil
+	}
+	sibling, ok := siblingValue(customValidator, params[0])
+	if !ok {
+		return nil
+	}
+	siblingString := valueString(sibling)
+	for _, expected := range params[1:] {
+		if siblingString == expected {
+			return v.requireField("required_if", customValidator, parentProperty...)
+		}
+	}
+	return nil
+}
+
+func (v validatorImpl[T]) validateRequiredWith(params []string, customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
+	for _, name := range params {
+		if v.siblingPresent(customValidator, name) {
+			return v.requireField("required_with", customValidator, parentProperty...)
+		}
+	}
+	return nil
+}
+
+func (v validatorImpl[T]) validateRequiredWithout(params []string, customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
+	for _, name := range params {
+		if _, ok := siblingValue(customValidator, name); ok && !v.siblingPresent(customValidator, name) {
+			return v.requireField("required_without", customValidator, parentProperty...)
+		}
+	}
+	return nil
+}
+
+// requireField applies the required check on behalf of a conditional rule,
+// reporting 40002 Missing Mandatory Field under the conditional rule's name.
+func (v validatorImpl[T]) requireField(validatorKey string, customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
+	errorCode := "40002"
+	err := v.ValidateRequired(interfaceOf(customValidator.FieldValue))
+	if err != nil {
+		return v.parsingError(customValidator, errorCode, validatorKey, parentProperty...)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package validator
+
+import (
+	"github.com/apelweb15/snap-validator/internal/models"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// resolvePath follows a dotted path of JSON (or Go) field names and slice
+// indices. Paths starting with "$." are resolved from the validated root,
+// other paths from the struct holding the field being validated.
+func (v validatorImpl[T]) resolvePath(customValidator models.CustomValidator, path string) (reflect.Value, bool) {
+	current := customValidator.Struct
+	if rest, ok := strings.CutPrefix(path, "$."); ok {
+		current, path = v.root, rest
+	}
+	for _, segment := range strings.Split(path, ".") {
+		current = indirect(current)
+		switch current.Kind() {
+		case reflect.Struct:
+			field, ok := planFor(current.Type()).sibling(segment)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			current = current.Field(field.index)
+		case reflect.Slice, reflect.Array:
+			idx, err := strconv.Atoi(segment)
+			if err != nil || idx < 0 || idx >= current.Len() {
+				return reflect.Value{}, false
+			}
+			current = current.Index(idx)
+		default:
+			return reflect.Value{}, false
+		}
+	}
+	return indirect(current), true
+}
+
+// compareValues orders two dereferenced values. ISO dates are compared as
+// instants, decimal strings, numbers and amount objects ({value, currency})
+// numerically. ok is false when the values are not comparable, e.g. two
+// strings that are neither dates nor numbers, or two amounts in different
+// currencies.
+func compareValues(a reflect.Value, b reflect.Value) (cmp int, ok bool) {
+	if !a.IsValid() || !b.IsValid() {
+		return 0, false
+	}
+	if currencyA, okA := amountCurrency(a); okA {
+		if currencyB, okB := amountCurrency(b); okB && currencyA != currencyB {
+			return 0, false
+		}
+	}
+	a, b = amountValue(a), amountValue(b)
+	if a.Kind() == reflect.String && b.Kind() == reflect.String {
+		aTime, errA := time.Parse("2006-01-02T15:04:05-07:00", a.String())
+		bTime, errB := time.Parse("2006-01-02T15:04:05-07:00", b.String())
+		if errA == nil && errB == nil {
+			return aTime.Compare(bTime), true
+		}
+	}
+	aNumber, okA := numberOf(a)
+	bNumber, okB := numberOf(b)
+	if okA && okB {
+		return aNumber.Cmp(bNumber), true
+	}
+	return 0, false
+}
+
+// amountValue returns the Value field of an amount object, such as
+// snapmodels.Amount, and value itself otherwise.
+func amountValue(value reflect.Value) reflect.Value {
+	if value.Kind() != reflect.Struct {
+		return value
+	}
+	if field, ok := amountValueField(value.Type()); ok {
+		return value.Field(field)
+	}
+	return value
+}
+
+// amountCurrency returns the Currency field of an amount object. ok is false
+// for other values.
+func amountCurrency(value reflect.Value) (currency string, ok bool) {
+	if _, ok := amountValueField(value.Type()); !ok {
+		return "", false
+	}
+	field := value.FieldByName("Currency")
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return "", false
+	}
+	return field.String(), true
+}
+
+// amountValueField returns the index of the string Value field of an amount
+// struct type.
+func amountValueField(reflectType reflect.Type) (int, bool) {
+	if reflectType.Kind() != reflect.Struct {
+		return 0, false
+	}
+	field, ok := reflectType.FieldByName("Value")
+	if !ok || len(field.Index) != 1 || field.Type.Kind() != reflect.String {
+		return 0, false
+	}
+	return field.Index[0], true
+}
+
+func numberOf(value reflect.Value) (*big.Rat, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Rat).SetUint64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		number := new(big.Rat).SetFloat64(value.Float())
+		return number, number != nil
+	case reflect.String:
+		return new(big.Rat).SetString(value.String())
+	}
+	return nil, false
+}
+
+func isIsoDate(value reflect.Value) bool {
+	if value.Kind() != reflect.String {
+		return false
+	}
+	_, err := time.Parse("2006-01-02T15:04:05-07:00", value.String())
+	return err == nil
+}
+
+// validateCompareField implements eq_field, gt_field, before_field and
+// after_field. The rule is skipped when either side is absent; combine it
+// with required to make the field mandatory.
+func (v validatorImpl[T]) validateCompareField(validatorKey string, path string, customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
+	errorCode := "40001"
+	value := customValidator.FieldValue
+	if v.ValidateRequired(interfaceOf(value)) != nil {
+		return nil
+	}
+	other, ok := v.resolvePath(customValidator, path)
+	if !ok || v.ValidateRequired(interfaceOf(other)) != nil {
+		return nil
+	}
+
+	valid := false
+	suffix := ""
+	otherLabel := strings.TrimPrefix(path, "$.")
+	switch validatorKey {
+	case "eq_field":
+		suffix = v.ruleMessage("eq_field", otherLabel)
+		if cmp, comparable := compareValues(value, other); comparable {
+			valid = cmp == 0
+		} else {
+			valid = reflect.DeepEqual(value.Interface(), other.Interface())
+		}
+	case "gt_field":
+		suffix = v.ruleMessage("gt_field", otherLabel)
+		cmp, comparable := compareValues(value, other)
+		valid = comparable && cmp > 0
+	case "before_field":
+		suffix = v.ruleMessage("before_field", otherLabel)
+		cmp, comparable := compareValues(value, other)
+		valid = comparable && isIsoDate(value) && isIsoDate(other) && cmp < 0
+	case "after_field":
+		suffix = v.ruleMessage("after_field", otherLabel)
+		cmp, comparable := compareValues(value, other)
+		valid = comparable && isIsoDate(value) && isIsoDate(other) && cmp > 0
+	}
+	if !valid {
+		return v.parsingErrorWithSuffix(suffix, customValidator, errorCode, validatorKey, parentProperty...)
+	}
+	return nil
+}
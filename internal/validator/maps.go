@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"fmt"
+	"github.com/apelweb15/snap-validator/internal/models"
+	"github.com/apelweb15/snap-validator/snap_validator_errors"
+	"reflect"
+	"sort"
+)
+
+// validateMap applies the keys and values rules of field to every entry of
+// the map in fieldValue and descends into struct values. Entries are visited
+// in key order so the reported error is deterministic.
+func (v validatorImpl[T]) validateMap(field fieldPlan, fieldValue reflect.Value, structValue reflect.Value, parentProperty ...models.ValidatorProperty) error {
+	var errs snap_validator_errors.ValidationErrors
+	fieldType := field.field
+
+	// Each key is printed once so that sorting does not allocate per
+	// comparison.
+	type mapEntry struct {
+		key     reflect.Value
+		printed string
+	}
+	entries := make([]mapEntry, 0, fieldValue.Len())
+	for _, key := range fieldValue.MapKeys() {
+		entries = append(entries, mapEntry{key: key, printed: fmt.Sprint(key.Interface())})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].printed < entries[j].printed
+	})
+	for _, entry := range entries {
+		key := entry.key
+		keyString := valueString(indirect(key))
+		elemValue := indirect(fieldValue.MapIndex(key))
+		element := models.CustomValidator{
+			FieldType:    fieldType,
+			Struct:       structValue,
+			Element:      true,
+			ElementKey:   keyString,
+			ElementIndex: -1,
+		}
+
+		element.FieldValue = indirect(key)
+		errElem := v.process(element, field.keyRules, parentProperty...)
+		if errElem == nil && field.dive {
+			element.FieldValue = elemValue
+			errElem = v.process(element, field.elemRules, parentProperty...)
+		}
+		if errElem != nil {
+			if !v.collectAll {
+				return errElem
+			}
+			errs = appendError(errs, errElem)
+			continue
+		}
+
+		parentProperty := append(parentProperty, models.ValidatorProperty{
+			FieldName:  fieldType.Name,
+			JsonName:   field.jsonName,
+			FieldType:  fieldType,
+			FieldValue: fieldValue,
+			Map:        true,
+			MapKey:     keyString,
+		})
+		errValue := v.validate(elemValue, parentProperty...)
+		if errValue != nil {
+			if !v.collectAll {
+				return errValue
+			}
+			errs = appendError(errs, errValue)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateRequiredKeys reports the first listed key that is missing from the
+// map or holds an empty value, labelled as the map entry, e.g.
+// additionalInfo.deviceId.
+func (v validatorImpl[T]) validateRequiredKeys(keys []string, customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
+	errorCode := "40002"
+	value := customValidator.FieldValue
+	if value.Kind() != reflect.Map || value.Type().Key().Kind() != reflect.String {
+		return nil
+	}
+	for _, key := range keys {
+		entry := value.MapIndex(reflect.ValueOf(key).Convert(value.Type().Key()))
+		if entry.IsValid() && v.ValidateRequired(interfaceOf(indirect(entry))) == nil {
+			continue
+		}
+		element := customValidator
+		element.Element = true
+		element.ElementKey = key
+		element.ElementIndex = -1
+		element.FieldValue = indirect(entry)
+		return v.parsingError(element, errorCode, "required_keys", parentProperty...)
+	}
+	return nil
+}
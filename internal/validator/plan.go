@@ -0,0 +1,134 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// structPlan is the parsed form of the snapValidator tags of a struct type.
+// Plans are built once per type and cached, so the hot path does not split
+// tags or parse rule parameters again.
+type structPlan struct {
+	fields []fieldPlan
+	// fieldIndex maps JSON names and Go field names to positions in fields,
+	// for rules that reference a sibling field.
+	fieldIndex map[string]int
+}
+
+// sibling returns the plan of the field named name (JSON name first, then Go
+// field name).
+func (p *structPlan) sibling(name string) (fieldPlan, bool) {
+	i, ok := p.fieldIndex[name]
+	if !ok {
+		return fieldPlan{}, false
+	}
+	return p.fields[i], true
+}
+
+type fieldPlan struct {
+	index    int
+	field    reflect.StructField
+	jsonName string
+	rules    []rulePlan
+	// dive is set when the tag contains the dive or values modifier;
+	// elemRules are the rules after it, applied to every element of a slice
+	// or array or every value of a map. keyRules follow the keys modifier
+	// and apply to map keys.
+	dive      bool
+	elemRules []rulePlan
+	keyRules  []rulePlan
+}
+
+type rulePlan struct {
+	name   string
+	param  string
+	params []string
+	// length is the parsed parameter of min_length and max_length.
+	length int
+	// err is set when the parameter cannot be parsed; the rule then fails
+	// with a 500xx00 General Error when it is evaluated.
+	err error
+}
+
+var planCache sync.Map // map[reflect.Type]*structPlan
+
+func planFor(reflectType reflect.Type) *structPlan {
+	if plan, ok := planCache.Load(reflectType); ok {
+		return plan.(*structPlan)
+	}
+	plan, _ := planCache.LoadOrStore(reflectType, compilePlan(reflectType))
+	return plan.(*structPlan)
+}
+
+// ResetPlanCache drops every compiled plan, e.g. to benchmark validation
+// without the cache.
+func ResetPlanCache() {
+	planCache.Range(func(key, _ interface{}) bool {
+		planCache.Delete(key)
+		return true
+	})
+}
+
+func compilePlan(reflectType reflect.Type) *structPlan {
+	plan := &structPlan{fieldIndex: map[string]int{}}
+	for i := 0; i < reflectType.NumField(); i++ {
+		fieldType := reflectType.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+		field := fieldPlan{
+			index:    i,
+			field:    fieldType,
+			jsonName: fieldType.Tag.Get("json"),
+		}
+		section := &field.rules
+		for _, rule := range compileRules(fieldType.Tag.Get("snapValidator")) {
+			switch rule.name {
+			case "dive", "values":
+				field.dive = true
+				section = &field.elemRules
+			case "keys":
+				section = &field.keyRules
+			default:
+				*section = append(*section, rule)
+			}
+		}
+		plan.fields = append(plan.fields, field)
+	}
+	// Go names are registered first so a JSON name wins when they collide.
+	for i, field := range plan.fields {
+		plan.fieldIndex[field.field.Name] = i
+	}
+	for i, field := range plan.fields {
+		plan.fieldIndex[jsonName(field.field.Name, field.jsonName)] = i
+	}
+	return plan
+}
+
+func compileRules(snapTag string) []rulePlan {
+	var rules []rulePlan
+	for _, validation := range strings.Split(snapTag, "|") {
+		if validation == "" {
+			continue
+		}
+		validationType, validationParam, _ := strings.Cut(validation, ":")
+		rule := rulePlan{
+			name:   validationType,
+			param:  validationParam,
+			params: splitParams(validationParam),
+		}
+		switch validationType {
+		case "min_length", "max_length", "min_items", "max_items":
+			if validationParam == "" {
+				rule.err = fmt.Errorf("%s requires an integer parameter", validationType)
+				break
+			}
+			rule.length, rule.err = strconv.Atoi(validationParam)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
@@ -0,0 +1,138 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"github.com/apelweb15/snap-validator/internal/models"
+	"github.com/apelweb15/snap-validator/snap_validator_errors"
+	"strings"
+	"sync"
+)
+
+type paramSpec int
+
+const (
+	paramNone paramSpec = iota
+	paramInt
+	paramList
+	// paramFieldValues is a sibling field followed by at least one value.
+	paramFieldValues
+	// paramFields is a list of sibling fields.
+	paramFields
+	// paramPath is a field path, relative to the struct or absolute ($.x).
+	paramPath
+)
+
+// ruleSpec describes a built-in rule for Compile: what parameter it takes and
+// whether it only applies to string fields.
+type ruleSpec struct {
+	param          paramSpec
+	stringOnly     bool
+	collectionOnly bool
+	mapOnly        bool
+	// orderedOnly rules need strings, numbers or amount objects.
+	orderedOnly bool
+}
+
+// builtinRules lists the rule names handled by process. Custom rules may not
+// shadow them.
+var builtinRules = map[string]ruleSpec{
+	"required":             {param: paramNone},
+	"min_length":           {param: paramInt, stringOnly: true},
+	"max_length":           {param: paramInt, stringOnly: true},
+	"iso_date":             {param: paramNone, stringOnly: true},
+	"after_time_now":       {param: paramNone, stringOnly: true},
+	"alpha_numeric":        {param: paramNone, stringOnly: true},
+	"alpha_numeric_symbol": {param: paramNone, stringOnly: true},
+	"numeric":              {param: paramNone, stringOnly: true},
+	"string":               {param: paramNone, stringOnly: true},
+	"amount":               {param: paramNone, stringOnly: true},
+	"email":                {param: paramNone, stringOnly: true},
+	"url":                  {param: paramNone, stringOnly: true},
+	"in_data":              {param: paramList, stringOnly: true},
+	"eq_field":             {param: paramPath},
+	"gt_field":             {param: paramPath, orderedOnly: true},
+	"before_field":         {param: paramPath, stringOnly: true},
+	"after_field":          {param: paramPath, stringOnly: true},
+	"min_items":            {param: paramInt, collectionOnly: true},
+	"max_items":            {param: paramInt, collectionOnly: true},
+	"unique":               {param: paramNone, collectionOnly: true},
+	"required_keys":        {param: paramList, mapOnly: true},
+	"required_if":          {param: paramFieldValues},
+	"required_with":        {param: paramFields},
+	"required_without":     {param: paramFields},
+}
+
+// ruleRegistry holds custom rules. It is shared by pointer so rules
+// registered after construction are visible to every copy of the validator.
+type ruleRegistry struct {
+	mu    sync.RWMutex
+	rules map[string]models.RuleFunc
+}
+
+func newRuleRegistry() *ruleRegistry {
+	return &ruleRegistry{rules: map[string]models.RuleFunc{}}
+}
+
+func (r *ruleRegistry) register(name string, rule models.RuleFunc) error {
+	if name == "" || strings.ContainsAny(name, "|: ") {
+		return fmt.Errorf("snap validator: invalid rule name %q", name)
+	}
+	if rule == nil {
+		return fmt.Errorf("snap validator: rule %q has no function", name)
+	}
+	if _, ok := builtinRules[name]; ok {
+		return fmt.Errorf("snap validator: rule %q is built in", name)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules[name] = rule
+	return nil
+}
+
+func (r *ruleRegistry) get(name string) (models.RuleFunc, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rule, ok := r.rules[name]
+	return rule, ok
+}
+
+func (v validatorImpl[T]) RegisterRule(name string, rule models.RuleFunc) error {
+	return v.rules.register(name, rule)
+}
+
+// splitParams splits a rule parameter such as "[IDR,USD]" or "12345" into
+// its comma separated values.
+func splitParams(param string) []string {
+	param = strings.TrimSuffix(strings.TrimPrefix(param, "["), "]")
+	if param == "" {
+		return nil
+	}
+	return strings.Split(param, ",")
+}
+
+func (v validatorImpl[T]) validateCustomRule(name string, rule models.RuleFunc, customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
+	err := rule(models.RuleContext{
+		Value:       interfaceOf(customValidator.FieldValue),
+		FieldValue:  customValidator.FieldValue,
+		Field:       customValidator.FieldType,
+		Param:       customValidator.Param,
+		Params:      splitParams(customValidator.Param),
+		Path:        v.fieldLabel(customValidator, parentProperty...),
+		Parents:     parentProperty,
+		ServiceCode: v.serviceCode,
+	})
+	if err == nil {
+		return nil
+	}
+	errorCode := "40001"
+	var errorRes *snap_validator_errors.ErrorValidation
+	if errors.As(err, &errorRes) && errorRes.Code != "" {
+		errorCode = errorRes.Code
+	}
+	validationErr := v.parsingError(customValidator, errorCode, name, parentProperty...)
+	if errorRes, ok := validationErr.(*snap_validator_errors.ErrorValidation); ok {
+		errorRes.Err = err
+	}
+	return validationErr
+}
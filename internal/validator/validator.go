@@ -1,10 +1,13 @@
 package validator
 
-import "github.com/apelweb15/snap-validator/internal/models"
+import (
+	"github.com/apelweb15/snap-validator/internal/models"
+	"github.com/apelweb15/snap-validator/snap_validator_errors"
+)
 
 // Validator /*Available Validation
 /*
-required = [string, struct, slice, numeric = 0]
+required = [string, struct, slice, map, numeric = 0, nil pointer or interface]
 min_length = [string]
 max_length = [string]
 iso_date = [string]
@@ -15,11 +18,29 @@ numeric = [string]
 string = [string]
 amount = [string] = ISO4217
 email = [string]
+url = [string]
 in_data = [string] = comparing value with defined list data
+min_items = [slice, array, map] minimum number of items
+max_items = [slice, array, map] maximum number of items
+unique = [slice, array] items must not repeat
+dive = rules after dive apply to every item of the slice, e.g. min_items:1|dive|required|max_length:10
+required_keys:[a,b] = [map] keys that must be present with a non empty value
+keys = [map] rules after keys apply to every map key, until values
+values = [map] rules after values (or dive) apply to every map value, e.g. keys|max_length:20|values|string
+required_if:field,value[,value] = required when sibling field (JSON name) equals one of the values
+required_with:field[,field] = required when any of the sibling fields is present
+required_without:field[,field] = required when any of the sibling fields is absent
+eq_field:path = equal to the field at path (sibling.path or $.absolute.path), numerically for amounts
+gt_field:path = [string, numeric, amount] amount or number greater than the field at path
+before_field:path = [string] iso date before the field at path
+after_field:path = [string] iso date after the field at path
+
+Rules registered with RegisterRule run for any other rule name.
 */
 type Validator[T any] interface {
 	ValidateStructSnap(any interface{}, parentProperty ...models.ValidatorProperty) error
 	ValidateStructSnapServiceCode(any interface{}, serviceCode string, parentProperty ...models.ValidatorProperty) error
+	ValidateStructSnapLocale(any interface{}, serviceCode string, locale snap_validator_errors.Locale, parentProperty ...models.ValidatorProperty) error
 	ValidateRequired(data interface{}) error
 	ValidateMaxLength(length int, data interface{}) error
 	ValidateMinLength(length int, data interface{}) error
@@ -33,4 +54,9 @@ type Validator[T any] interface {
 	ValidateInData(allowed []any, data interface{}) error
 	ValidateEmail(data interface{}) error
 	ValidateUrl(data interface{}) error
+	ValidateMinItems(count int, data interface{}) error
+	ValidateMaxItems(count int, data interface{}) error
+	ValidateUnique(data interface{}) error
+	RegisterRule(name string, rule models.RuleFunc) error
+	Compile(data interface{}) []models.CompileIssue
 }
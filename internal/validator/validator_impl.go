@@ -14,107 +14,256 @@ import (
 	"time"
 )
 
+var (
+	alphaNumRegexp       = regexp.MustCompile(`^[a-zA-Z\d]+$`)
+	alphaNumSymbolRegexp = regexp.MustCompile(`^[a-zA-Z\d_-]+$`)
+	numericRegexp        = regexp.MustCompile(`^\d+$`)
+	stringRegexp         = regexp.MustCompile(`^[a-zA-Z\d:; )(\+\/\&\#\!\@\%\*.,\'\"_-]+$`)
+	amountRegexp         = regexp.MustCompile(`^(0|[1-9]\d{0,10})[.]0{2}$`)
+)
+
 type validatorImpl[T any] struct {
 	customValidation []map[string]string
 	serviceCode      string
+	collectAll       bool
+	rules            *ruleRegistry
+	messages         *snap_validator_errors.Messages
+	locale           snap_validator_errors.Locale
+	// root is the struct passed to the current validation call; absolute
+	// field paths ($.field) are resolved against it.
+	root reflect.Value
+}
+
+// Options configure a validator built with NewWithOptions.
+type Options struct {
+	// CollectAll keeps walking the struct after a failing field and returns
+	// every failure as snap_validator_errors.ValidationErrors.
+	CollectAll bool
+	// CustomMessages maps "path:rule" keys to messages that replace the
+	// default SNAP message of a failing rule.
+	CustomMessages map[string]string
+	// Messages is the catalog used to render SNAP and rule messages;
+	// snap_validator_errors.DefaultMessages when nil.
+	Messages *snap_validator_errors.Messages
+	// Locale is the default locale of messages, English when empty.
+	Locale snap_validator_errors.Locale
 }
 
 func New[T any](serviceCode string, customValidation ...map[string]string) Validator[T] {
 	return &validatorImpl[T]{
 		serviceCode:      serviceCode,
 		customValidation: customValidation,
+		rules:            newRuleRegistry(),
 	}
 }
 
 func NewService[T any](customValidation ...map[string]string) Validator[T] {
 	return validatorImpl[T]{
 		customValidation: customValidation,
+		rules:            newRuleRegistry(),
+	}
+}
+
+func NewWithOptions[T any](options Options) Validator[T] {
+	var customValidation []map[string]string
+	if len(options.CustomMessages) > 0 {
+		customValidation = append(customValidation, options.CustomMessages)
+	}
+	return validatorImpl[T]{
+		customValidation: customValidation,
+		collectAll:       options.CollectAll,
+		rules:            newRuleRegistry(),
+		messages:         options.Messages,
+		locale:           options.Locale,
 	}
 }
 
 func (v validatorImpl[T]) ValidateStructSnap(data interface{}, parentProperty ...models.ValidatorProperty) error {
-	return v.validate(data, parentProperty...)
+	return v.validateRoot(data, parentProperty...)
 }
 
 func (v validatorImpl[T]) ValidateStructSnapServiceCode(data interface{}, serviceCode string, parentProperty ...models.ValidatorProperty) error {
 	v.serviceCode = serviceCode
-	return v.validate(data, parentProperty...)
+	return v.validateRoot(data, parentProperty...)
 }
 
-func (v validatorImpl[T]) validate(data interface{}, parentProperty ...models.ValidatorProperty) error {
-	reflectType := reflect.TypeOf(data)
-	reflectValue := reflect.ValueOf(data)
-	for i := 0; i < reflectType.NumField(); i++ {
-		fieldType := reflectType.Field(i)
-		fieldValue := reflectValue.Field(i)
+func (v validatorImpl[T]) ValidateStructSnapLocale(data interface{}, serviceCode string, locale snap_validator_errors.Locale, parentProperty ...models.ValidatorProperty) error {
+	v.serviceCode = serviceCode
+	if locale != "" {
+		v.locale = locale
+	}
+	return v.validateRoot(data, parentProperty...)
+}
+
+func (v validatorImpl[T]) validateRoot(data interface{}, parentProperty ...models.ValidatorProperty) error {
+	reflectValue := indirect(reflect.ValueOf(data))
+	if !reflectValue.IsValid() {
+		return snap_validator_errors.NewError("40000", "")
+	}
+	v.root = reflectValue
+	return v.validate(reflectValue, parentProperty...)
+}
+
+func (v validatorImpl[T]) validate(reflectValue reflect.Value, parentProperty ...models.ValidatorProperty) error {
+	if reflectValue.Kind() != reflect.Struct {
+		return nil
+	}
+	var errs snap_validator_errors.ValidationErrors
+	plan := planFor(reflectValue.Type())
+	for _, field := range plan.fields {
+		fieldType := field.field
+		rawValue := reflectValue.Field(field.index)
+		fieldValue := indirect(rawValue)
 		errValidation := v.process(models.CustomValidator{
 			FieldType:  fieldType,
 			FieldValue: fieldValue,
-		}, parentProperty...)
+			Struct:     reflectValue,
+		}, field.rules, parentProperty...)
 		if errValidation != nil {
-			return errValidation
+			if !v.collectAll {
+				return errValidation
+			}
+			errs = appendError(errs, errValidation)
+			continue
 		}
 
-		if fieldType.Type.Kind() == reflect.Struct {
-			var f = reflect.ValueOf(fieldValue.Interface())
-			if !reflect.DeepEqual(fieldValue.Interface(), reflect.Zero(f.Type()).Interface()) {
+		switch fieldValue.Kind() {
+		case reflect.Struct:
+			// A plain struct left at its zero value is treated as absent, while
+			// a non-nil pointer or interface means the object was sent.
+			present := rawValue.Kind() == reflect.Ptr || rawValue.Kind() == reflect.Interface
+			if present || !fieldValue.IsZero() {
 				parentProperty := append(parentProperty, models.ValidatorProperty{
 					FieldName:  fieldType.Name,
-					JsonName:   fieldType.Tag.Get("json"),
+					JsonName:   field.jsonName,
 					Array:      false,
 					FieldType:  fieldType,
 					FieldValue: fieldValue,
 				})
-				errStruct := v.validate(fieldValue.Interface(), parentProperty...)
+				errStruct := v.validate(fieldValue, parentProperty...)
 				if errStruct != nil {
-					return errStruct
+					if !v.collectAll {
+						return errStruct
+					}
+					errs = appendError(errs, errStruct)
 				}
 			}
-		}
-
-		if fieldType.Type.Kind() == reflect.Slice {
-			var f = reflect.ValueOf(fieldValue.Interface())
-			ret := make([]reflect.Value, f.Len())
-			for j := 0; j < f.Len(); j++ {
-				ret[j] = f.Index(j)
+		case reflect.Slice, reflect.Array:
+			for j := 0; j < fieldValue.Len(); j++ {
+				elemValue := indirect(fieldValue.Index(j))
+				if field.dive {
+					errElem := v.process(models.CustomValidator{
+						FieldType:    fieldType,
+						FieldValue:   elemValue,
+						Struct:       reflectValue,
+						Element:      true,
+						ElementKey:   strconv.Itoa(j),
+						ElementIndex: j,
+					}, field.elemRules, parentProperty...)
+					if errElem != nil {
+						if !v.collectAll {
+							return errElem
+						}
+						errs = appendError(errs, errElem)
+						continue
+					}
+				}
 
 				parentProperty := append(parentProperty, models.ValidatorProperty{
 					FieldName:  fieldType.Name,
-					JsonName:   fieldType.Tag.Get("json"),
+					JsonName:   field.jsonName,
 					Array:      true,
 					FieldType:  fieldType,
 					FieldValue: fieldValue,
 					IdxArray:   j,
 				})
 
-				errSlice := v.validate(f.Index(j).Interface(), parentProperty...)
+				errSlice := v.validate(elemValue, parentProperty...)
 				if errSlice != nil {
-					return errSlice
+					if !v.collectAll {
+						return errSlice
+					}
+					errs = appendError(errs, errSlice)
+				}
+			}
+		case reflect.Map:
+			errMap := v.validateMap(field, fieldValue, reflectValue, parentProperty...)
+			if errMap != nil {
+				if !v.collectAll {
+					return errMap
 				}
+				errs = appendError(errs, errMap)
 			}
 		}
 	}
+	if len(errs) > 0 {
+		return errs
+	}
 	return nil
 }
 
-func (v validatorImpl[T]) process(customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
-	fieldType := customValidator.FieldType
-	//value := customValidator.FieldValue
-
-	snapTag := fieldType.Tag.Get("snapValidator")
-	listValidator := strings.Split(snapTag, "|")
-	for _, validation := range listValidator {
-		//region get validation type & value
-		validations := strings.Split(validation, ":")
-		validationParam := ""
-		validationType := validations[0]
-		if len(validations) == 2 {
-			validationParam = validations[1]
+// indirect follows pointers and interfaces down to the concrete value. A nil
+// pointer or interface yields the zero reflect.Value.
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
 		}
+		value = value.Elem()
+	}
+	return value
+}
+
+// interfaceOf is reflect.Value.Interface that maps the zero Value to nil, so
+// absent optional fields reach the rule functions as nil.
+func interfaceOf(value reflect.Value) interface{} {
+	if !value.IsValid() {
+		return nil
+	}
+	return value.Interface()
+}
+
+// appendError flattens err into errs, whether it is a single
+// *ErrorValidation or an aggregate returned by a nested walk.
+func appendError(errs snap_validator_errors.ValidationErrors, err error) snap_validator_errors.ValidationErrors {
+	switch e := err.(type) {
+	case snap_validator_errors.ValidationErrors:
+		return append(errs, e...)
+	case *snap_validator_errors.ErrorValidation:
+		return append(errs, e)
+	default:
+		return append(errs, &snap_validator_errors.ErrorValidation{
+			Code:     "50000",
+			SnapCode: "50000",
+			Message:  err.Error(),
+		})
+	}
+}
 
-		//endregion get validation type & value
+// malformedRuleError reports a rule whose parameter cannot be parsed as
+// 500xx00 General Error, with the parse error as Detail and cause.
+func (v validatorImpl[T]) malformedRuleError(rule rulePlan, customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
+	return &snap_validator_errors.ErrorValidation{
+		Code:      "50000",
+		SnapCode:  snap_validator_errors.SnapCode("50000", v.serviceCode),
+		Message:   v.catalog().SnapMessage(v.messageLocale(), "50000"),
+		FieldName: customValidator.FieldType.Name,
+		Path:      v.fieldLabel(customValidator, parentProperty...),
+		Rule:      rule.name,
+		Params:    splitParams(rule.param),
+		Detail:    rule.err.Error(),
+		Err:       rule.err,
+	}
+}
+
+func (v validatorImpl[T]) process(customValidator models.CustomValidator, rules []rulePlan, parentProperty ...models.ValidatorProperty) error {
+	for _, rule := range rules {
+		if rule.err != nil {
+			return v.malformedRuleError(rule, customValidator, parentProperty...)
+		}
+		customValidator.Param = rule.param
 
-		switch validationType {
+		switch rule.name {
 		case "required":
 			errorValidate := v.validateRequired(customValidator, parentProperty...)
 			if errorValidate != nil {
@@ -122,13 +271,13 @@ func (v validatorImpl[T]) process(customValidator models.CustomValidator, parent
 			}
 			break
 		case "min_length":
-			errorValidate := v.validateMinLength(validationParam, customValidator, parentProperty...)
+			errorValidate := v.validateMinLength(rule.length, customValidator, parentProperty...)
 			if errorValidate != nil {
 				return errorValidate
 			}
 			break
 		case "max_length":
-			errorValidate := v.validateMaxLength(validationParam, customValidator, parentProperty...)
+			errorValidate := v.validateMaxLength(rule.length, customValidator, parentProperty...)
 			if errorValidate != nil {
 				return errorValidate
 			}
@@ -182,13 +331,73 @@ func (v validatorImpl[T]) process(customValidator models.CustomValidator, parent
 				return errorValidate
 			}
 			break
+		case "url":
+			errorValidate := v.validateUrl(customValidator, parentProperty...)
+			if errorValidate != nil {
+				return errorValidate
+			}
+			break
 		case "in_data":
-			errorValidate := v.validateInData(validationParam, customValidator, parentProperty...)
+			errorValidate := v.validateInData(rule.params, customValidator, parentProperty...)
 			if errorValidate != nil {
 				return errorValidate
 			}
 			break
-
+		case "eq_field", "gt_field", "before_field", "after_field":
+			errorValidate := v.validateCompareField(rule.name, rule.param, customValidator, parentProperty...)
+			if errorValidate != nil {
+				return errorValidate
+			}
+			break
+		case "min_items":
+			errorValidate := v.validateMinItems(rule.length, customValidator, parentProperty...)
+			if errorValidate != nil {
+				return errorValidate
+			}
+			break
+		case "max_items":
+			errorValidate := v.validateMaxItems(rule.length, customValidator, parentProperty...)
+			if errorValidate != nil {
+				return errorValidate
+			}
+			break
+		case "unique":
+			errorValidate := v.validateUnique(customValidator, parentProperty...)
+			if errorValidate != nil {
+				return errorValidate
+			}
+			break
+		case "required_keys":
+			errorValidate := v.validateRequiredKeys(rule.params, customValidator, parentProperty...)
+			if errorValidate != nil {
+				return errorValidate
+			}
+			break
+		case "required_if":
+			errorValidate := v.validateRequiredIf(rule.params, customValidator, parentProperty...)
+			if errorValidate != nil {
+				return errorValidate
+			}
+			break
+		case "required_with":
+			errorValidate := v.validateRequiredWith(rule.params, customValidator, parentProperty...)
+			if errorValidate != nil {
+				return errorValidate
+			}
+			break
+		case "required_without":
+			errorValidate := v.validateRequiredWithout(rule.params, customValidator, parentProperty...)
+			if errorValidate != nil {
+				return errorValidate
+			}
+			break
+		default:
+			if customRule, ok := v.rules.get(rule.name); ok {
+				errorValidate := v.validateCustomRule(rule.name, customRule, customValidator, parentProperty...)
+				if errorValidate != nil {
+					return errorValidate
+				}
+			}
 		}
 
 	}
@@ -198,12 +407,14 @@ func (v validatorImpl[T]) process(customValidator models.CustomValidator, parent
 func (v validatorImpl[T]) ValidateRequired(data interface{}) error {
 	value := reflect.ValueOf(data)
 	isValid := true
-	if value.Kind() == reflect.String {
+	if !value.IsValid() {
+		isValid = false
+	} else if value.Kind() == reflect.String {
 		if data.(string) == "" {
 			isValid = false
 		}
 	} else if value.Kind() == reflect.Struct {
-		if reflect.DeepEqual(data, reflect.Zero(value.Type()).Interface()) {
+		if value.IsZero() {
 			isValid = false
 		}
 	} else if snap_validator_utils.KindIsNumeric(value.Kind()) {
@@ -211,17 +422,15 @@ func (v validatorImpl[T]) ValidateRequired(data interface{}) error {
 		if n <= 0 {
 			isValid = false
 		}
-	} else if value.Kind() == reflect.Slice {
-		if reflect.DeepEqual(value.Interface(), reflect.Zero(value.Type()).Interface()) {
-			isValid = false
-		}
+	} else if value.Kind() == reflect.Slice || value.Kind() == reflect.Map {
 		if value.Len() == 0 {
 			isValid = false
 		}
 	} else if value.Kind() == reflect.Ptr {
-		rawType := reflect.TypeOf(data).Elem()
-		if reflect.DeepEqual(data, reflect.New(rawType).Interface()) {
+		if value.IsNil() {
 			isValid = false
+		} else {
+			return v.ValidateRequired(value.Elem().Interface())
 		}
 	}
 	if !isValid {
@@ -236,7 +445,7 @@ func (v validatorImpl[T]) ValidateRequired(data interface{}) error {
 func (v validatorImpl[T]) validateRequired(customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
 	value := customValidator.FieldValue
 	errorCode := "40002"
-	err := v.ValidateRequired(value.Interface())
+	err := v.ValidateRequired(interfaceOf(value))
 	if err != nil {
 		return v.parsingError(customValidator, errorCode, "required", parentProperty...)
 	}
@@ -262,18 +471,10 @@ func (v validatorImpl[T]) ValidateMaxLength(length int, data interface{}) error
 	return nil
 }
 
-func (v validatorImpl[T]) validateMaxLength(length string, customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
-	fieldType := customValidator.FieldType
+func (v validatorImpl[T]) validateMaxLength(length int, customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
 	fieldValue := customValidator.FieldValue
 	errorCode := "40001"
-	if length == "" {
-		length = "0"
-	}
-	lengthInt, err := strconv.Atoi(length)
-	if err != nil {
-		return snap_validator_errors.NewError("500000", fieldType.Name)
-	}
-	errValidation := v.ValidateMaxLength(lengthInt, fieldValue.Interface())
+	errValidation := v.ValidateMaxLength(length, interfaceOf(fieldValue))
 	if errValidation != nil {
 		return v.parsingError(customValidator, errorCode, "max_length", parentProperty...)
 	}
@@ -299,18 +500,10 @@ func (v validatorImpl[T]) ValidateMinLength(length int, data interface{}) error
 	return nil
 }
 
-func (v validatorImpl[T]) validateMinLength(length string, customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
-	fieldType := customValidator.FieldType
+func (v validatorImpl[T]) validateMinLength(length int, customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
 	fieldValue := customValidator.FieldValue
 	errorCode := "40001"
-	if length == "" {
-		length = "0"
-	}
-	lengthInt, err := strconv.Atoi(length)
-	if err != nil {
-		return snap_validator_errors.NewError("500000", fieldType.Name)
-	}
-	errValidation := v.ValidateMinLength(lengthInt, fieldValue.Interface())
+	errValidation := v.ValidateMinLength(length, interfaceOf(fieldValue))
 	if errValidation != nil {
 		return v.parsingError(customValidator, errorCode, "min_length", parentProperty...)
 	}
@@ -338,7 +531,7 @@ func (v validatorImpl[T]) validateIsoDate(customValidator models.CustomValidator
 	errorCode := "40001"
 	fieldValue := customValidator.FieldValue
 
-	err := v.ValidateIsoDate(fieldValue.Interface())
+	err := v.ValidateIsoDate(interfaceOf(fieldValue))
 	if err != nil {
 		return v.parsingError(customValidator, errorCode, "iso_date", parentProperty...)
 	}
@@ -371,9 +564,9 @@ func (v validatorImpl[T]) ValidateAfterTimeNow(data interface{}) error {
 func (v validatorImpl[T]) validateAfterTimeNow(customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
 	errorCode := "40001"
 	fieldValue := customValidator.FieldValue
-	err := v.ValidateAfterTimeNow(fieldValue.Interface())
+	err := v.ValidateAfterTimeNow(interfaceOf(fieldValue))
 	if err != nil {
-		return v.parsingErrorWithSuffix("must greater than now", customValidator, errorCode, "after_time_now", parentProperty...)
+		return v.parsingErrorWithSuffix(v.ruleMessage("after_time_now", ""), customValidator, errorCode, "after_time_now", parentProperty...)
 	}
 	return nil
 }
@@ -384,7 +577,7 @@ func (v validatorImpl[T]) ValidateAlphaNum(data interface{}) error {
 		if data.(string) == "" {
 			return nil
 		}
-		cond := regexp.MustCompile(`^[a-zA-Z\d]+$`)
+		cond := alphaNumRegexp
 		if !cond.MatchString(data.(string)) {
 			return &snap_validator_errors.ErrorValidation{
 				Code:    "40001",
@@ -400,7 +593,7 @@ func (v validatorImpl[T]) validateAlphaNum(customValidator models.CustomValidato
 	errorCode := "40001"
 	fieldValue := customValidator.FieldValue
 
-	err := v.ValidateAlphaNum(fieldValue.Interface())
+	err := v.ValidateAlphaNum(interfaceOf(fieldValue))
 	if err != nil {
 		return v.parsingError(customValidator, errorCode, "alpha_numeric", parentProperty...)
 	}
@@ -415,7 +608,7 @@ func (v validatorImpl[T]) ValidateAlphaNumSymbol(data interface{}) error {
 			return nil
 		}
 
-		cond := regexp.MustCompile(`^[a-zA-Z\d_-]+$`)
+		cond := alphaNumSymbolRegexp
 		if !cond.MatchString(data.(string)) {
 			return &snap_validator_errors.ErrorValidation{
 				Code:    "40001",
@@ -430,7 +623,7 @@ func (v validatorImpl[T]) ValidateAlphaNumSymbol(data interface{}) error {
 func (v validatorImpl[T]) validateAlphaNumSymbol(customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
 	errorCode := "40001"
 	fieldValue := customValidator.FieldValue
-	err := v.ValidateAlphaNumSymbol(fieldValue.Interface())
+	err := v.ValidateAlphaNumSymbol(interfaceOf(fieldValue))
 	if err != nil {
 		return v.parsingError(customValidator, errorCode, "alpha_numeric_symbol", parentProperty...)
 	}
@@ -444,7 +637,7 @@ func (v validatorImpl[T]) ValidateNumeric(data interface{}) error {
 		if data.(string) == "" {
 			return nil
 		}
-		cond := regexp.MustCompile(`^\d+$`)
+		cond := numericRegexp
 		if !cond.MatchString(strings.TrimSpace(data.(string))) {
 			return &snap_validator_errors.ErrorValidation{
 				Code:    "40001",
@@ -458,7 +651,7 @@ func (v validatorImpl[T]) ValidateNumeric(data interface{}) error {
 func (v validatorImpl[T]) validateNumeric(customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
 	errorCode := "40001"
 	fieldValue := customValidator.FieldValue
-	err := v.ValidateNumeric(fieldValue.Interface())
+	err := v.ValidateNumeric(interfaceOf(fieldValue))
 	if err != nil {
 		return v.parsingError(customValidator, errorCode, "numeric", parentProperty...)
 	}
@@ -471,7 +664,7 @@ func (v validatorImpl[T]) ValidateString(data interface{}) error {
 		if data.(string) == "" {
 			return nil
 		}
-		cond := regexp.MustCompile(`^[a-zA-Z\d:; )(\+\/\&\#\!\@\%\*.,\'\"_-]+$`)
+		cond := stringRegexp
 		if !cond.MatchString(data.(string)) {
 			return &snap_validator_errors.ErrorValidation{
 				Code:    "40001",
@@ -486,7 +679,7 @@ func (v validatorImpl[T]) validateString(customValidator models.CustomValidator,
 	errorCode := "40001"
 	fieldValue := customValidator.FieldValue
 
-	err := v.ValidateString(fieldValue.Interface())
+	err := v.ValidateString(interfaceOf(fieldValue))
 	if err != nil {
 		return v.parsingError(customValidator, errorCode, "string", parentProperty...)
 	}
@@ -499,7 +692,7 @@ func (v validatorImpl[T]) ValidateAmount(data interface{}) error {
 		if data.(string) == "" {
 			return nil
 		}
-		cond := regexp.MustCompile(`^(0|[1-9]\d{0,10})[.]0{2}$`)
+		cond := amountRegexp
 		if !cond.MatchString(data.(string)) {
 			return &snap_validator_errors.ErrorValidation{
 				Code:    "40001",
@@ -513,7 +706,7 @@ func (v validatorImpl[T]) ValidateAmount(data interface{}) error {
 func (v validatorImpl[T]) validateAmount(customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
 	errorCode := "40001"
 	fieldValue := customValidator.FieldValue
-	err := v.ValidateAmount(fieldValue.Interface())
+	err := v.ValidateAmount(interfaceOf(fieldValue))
 	if err != nil {
 		return v.parsingError(customValidator, errorCode, "amount", parentProperty...)
 	}
@@ -537,18 +730,14 @@ func (v validatorImpl[T]) ValidateInData(allowed []any, data interface{}) error
 	return nil
 }
 
-func (v validatorImpl[T]) validateInData(allowed string, customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
+func (v validatorImpl[T]) validateInData(allowed []string, customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) error {
 	errorCode := "40001"
-	fieldType := customValidator.FieldType
 	fieldValue := customValidator.FieldValue
-	if fieldType.Type.Kind() == reflect.String {
-		if fieldValue.Interface().(string) == "" {
+	if fieldValue.Kind() == reflect.String {
+		if fieldValue.String() == "" {
 			return nil
 		}
-		allowed = strings.ReplaceAll(allowed, "[", "")
-		allowed = strings.ReplaceAll(allowed, "]", "")
-		tSlice := strings.Split(allowed, ",")
-		exist, _ := snap_validator_utils.InArray(fieldValue.Interface().(string), tSlice)
+		exist, _ := snap_validator_utils.InArray(fieldValue.String(), allowed)
 		if !exist {
 			return v.parsingError(customValidator, errorCode, "in_data", parentProperty...)
 		}
@@ -577,7 +766,7 @@ func (v validatorImpl[T]) validateEmail(customValidator models.CustomValidator,
 	errorCode := "40001"
 	fieldValue := customValidator.FieldValue
 
-	err := v.ValidateEmail(fieldValue.Interface())
+	err := v.ValidateEmail(interfaceOf(fieldValue))
 	if err != nil {
 		return v.parsingError(customValidator, errorCode, "email", parentProperty...)
 	}
@@ -605,7 +794,7 @@ func (v validatorImpl[T]) validateUrl(customValidator models.CustomValidator, pa
 	errorCode := "40001"
 	fieldValue := customValidator.FieldValue
 
-	err := v.ValidateUrl(fieldValue.Interface())
+	err := v.ValidateUrl(interfaceOf(fieldValue))
 	if err != nil {
 		return v.parsingError(customValidator, errorCode, "url", parentProperty...)
 	}
@@ -614,126 +803,191 @@ func (v validatorImpl[T]) validateUrl(customValidator models.CustomValidator, pa
 
 func (v validatorImpl[T]) parsingErrorWithSuffix(suffix string, customValidator models.CustomValidator, code string, validatorKey string, parentProperty ...models.ValidatorProperty) error {
 	fieldType := customValidator.FieldType
-	label := v.getLabel(fieldType, parentProperty...)
-
-	if len(v.customValidation) > 0 {
-		customValidation := v.customValidation[0]
-		customMessage := customValidation[fieldType.Name+":"+validatorKey]
-		var indexIfUseArray []any
-		if len(parentProperty) > 0 {
-			keyLabel := ""
-			for _, parent := range parentProperty {
-				if keyLabel != "" {
-					keyLabel += "."
-				}
-				keyLabel += parent.FieldName
-				if parent.Array {
-					keyLabel += ".*"
-					indexIfUseArray = append(indexIfUseArray, parent.IdxArray)
-				}
-			}
-			if keyLabel != "" {
-				keyLabel += "." + fieldType.Name
-			}
-			customMessageParent := customValidation[keyLabel+":"+validatorKey]
-			if customMessageParent != "" {
-				if len(indexIfUseArray) > 0 {
-					customMessage = fmt.Sprintf(customMessageParent, indexIfUseArray...)
-				} else {
-					customMessage = customMessageParent
-				}
+	label := v.fieldLabel(customValidator, parentProperty...)
 
-				//
-			}
-		}
-		if customMessage != "" {
-			snapCode := code
-			if len(code) > 4 {
-				snapCode = code[:3] + v.serviceCode + code[3:5]
-			}
-			return &snap_validator_errors.ErrorValidation{
-				Code:      code,
-				SnapCode:  snapCode,
-				Message:   customMessage,
-				FieldName: fieldType.Name,
-			}
+	message := v.customMessage(customValidator, validatorKey, label, parentProperty...)
+	if message == "" {
+		message = v.catalog().SnapMessage(v.messageLocale(), code) + " " + label
+		if suffix != "" {
+			message += " " + suffix
 		}
 	}
+	params := splitParams(customValidator.Param)
+	return &snap_validator_errors.ErrorValidation{
+		Code:      code,
+		SnapCode:  snap_validator_errors.SnapCode(code, v.serviceCode),
+		Message:   message,
+		FieldName: fieldType.Name,
+		Path:      label,
+		Rule:      validatorKey,
+		Params:    params,
+		Value:     offendingValue(customValidator),
+		Detail:    v.catalog().RuleDetail(v.messageLocale(), validatorKey, params),
+	}
+}
+
+// maxReportedValue caps the length of ErrorValidation.Value.
+const maxReportedValue = 64
+
+// offendingValue formats the value of a failing scalar field for
+// ErrorValidation.Value, masking fields tagged snapMask:"true".
+func offendingValue(customValidator models.CustomValidator) string {
+	value := customValidator.FieldValue
+	switch value.Kind() {
+	case reflect.Invalid, reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		return ""
+	}
+	formatted := valueString(value)
+	if customValidator.FieldType.Tag.Get("snapMask") == "true" {
+		return snap_validator_utils.MaskValue(formatted)
+	}
+	if runes := []rune(formatted); len(runes) > maxReportedValue {
+		return string(runes[:maxReportedValue]) + "..."
+	}
+	return formatted
+}
+
+func (v validatorImpl[T]) catalog() *snap_validator_errors.Messages {
+	if v.messages != nil {
+		return v.messages
+	}
+	return snap_validator_errors.DefaultMessages()
+}
+
+func (v validatorImpl[T]) messageLocale() snap_validator_errors.Locale {
+	if v.locale != "" {
+		return v.locale
+	}
+	return snap_validator_errors.LocaleEnglish
+}
 
-	return snap_validator_errors.NewErrorSnap(code, label+" "+suffix, fieldType.Name, v.serviceCode)
-	//return error_snap.NewErrorSnap(code, label, fieldType.Name, v.serviceCode)
+// ruleMessage returns the localized explanation some rules append to the
+// SNAP message, e.g. "must greater than now".
+func (v validatorImpl[T]) ruleMessage(rule string, field string) string {
+	return v.catalog().RuleMessage(v.messageLocale(), rule, field)
 }
 
 func (v validatorImpl[T]) parsingError(customValidator models.CustomValidator, code string, validatorKey string, parentProperty ...models.ValidatorProperty) error {
+	return v.parsingErrorWithSuffix("", customValidator, code, validatorKey, parentProperty...)
+}
+
+// customMessage looks up a registered message for the failing rule. Keys are
+// tried from most to least specific: the Go field path with "*" for array
+// indices (BillDetails.*.BillCode:required), the JSON path
+// (billDetails.*.billCode:required) and finally the bare Go field name
+// (BillCode:required).
+//
+// Messages may contain {label}, {param}, {index} (innermost array index) and
+// {index0}, {index1}, ... (array indices from the outermost parent). The
+// older fmt form, one %d per array index, is still formatted; see
+// legacyIndexMessage.
+func (v validatorImpl[T]) customMessage(customValidator models.CustomValidator, validatorKey string, label string, parentProperty ...models.ValidatorProperty) string {
+	if len(v.customValidation) == 0 {
+		return ""
+	}
+	customValidation := v.customValidation[0]
 	fieldType := customValidator.FieldType
-	label := v.getLabel(fieldType, parentProperty...)
-
-	if len(v.customValidation) > 0 {
-		customValidation := v.customValidation[0]
-		customMessage := customValidation[fieldType.Name+":"+validatorKey]
-		var indexIfUseArray []any
-		if len(parentProperty) > 0 {
-			keyLabel := ""
-			for _, parent := range parentProperty {
-				if keyLabel != "" {
-					keyLabel += "."
-				}
-				keyLabel += parent.FieldName
-				if parent.Array {
-					keyLabel += ".*"
-					indexIfUseArray = append(indexIfUseArray, parent.IdxArray)
-				}
-			}
-			if keyLabel != "" {
-				keyLabel += "." + fieldType.Name
-			}
-			customMessageParent := customValidation[keyLabel+":"+validatorKey]
-			if customMessageParent != "" {
-				if len(indexIfUseArray) > 0 {
-					customMessage = fmt.Sprintf(customMessageParent, indexIfUseArray...)
-				} else {
-					customMessage = customMessageParent
-				}
-			}
+
+	var indexIfUseArray []int
+	keyField := ""
+	keyJson := ""
+	for _, parent := range parentProperty {
+		keyField += parent.FieldName + "."
+		keyJson += jsonName(parent.FieldName, parent.JsonName) + "."
+		if parent.Array {
+			keyField += "*."
+			keyJson += "*."
+			indexIfUseArray = append(indexIfUseArray, parent.IdxArray)
 		}
-		if customMessage != "" {
-			snapCode := code
-			if len(code) > 4 {
-				snapCode = code[:3] + v.serviceCode + code[3:5]
-			}
-			return &snap_validator_errors.ErrorValidation{
-				Code:      code,
-				SnapCode:  snapCode,
-				Message:   customMessage,
-				FieldName: fieldType.Name,
-			}
+		if parent.Map {
+			keyField += "*."
+			keyJson += "*."
 		}
 	}
+	keyField += fieldType.Name
+	keyJson += jsonName(fieldType.Name, fieldType.Tag.Get("json"))
+	if customValidator.Element {
+		keyField += ".*"
+		keyJson += ".*"
+		if customValidator.ElementIndex >= 0 {
+			indexIfUseArray = append(indexIfUseArray, customValidator.ElementIndex)
+		}
+	}
+
+	customMessage := ""
+	for _, key := range []string{keyField, keyJson, fieldType.Name} {
+		if message := customValidation[key+":"+validatorKey]; message != "" {
+			customMessage = message
+			break
+		}
+	}
+	if customMessage == "" {
+		return ""
+	}
+	customMessage = legacyIndexMessage(customMessage, indexIfUseArray)
 
-	return snap_validator_errors.NewErrorSnap(code, label, fieldType.Name, v.serviceCode)
-	//return error_snap.NewErrorSnap(code, label, fieldType.Name, v.serviceCode)
+	replacements := []string{
+		"{label}", label,
+		"{param}", customValidator.Param,
+	}
+	if len(indexIfUseArray) > 0 {
+		replacements = append(replacements, "{index}", strconv.Itoa(indexIfUseArray[len(indexIfUseArray)-1]))
+	}
+	for i, idx := range indexIfUseArray {
+		replacements = append(replacements, fmt.Sprintf("{index%d}", i), strconv.Itoa(idx))
+	}
+	return strings.NewReplacer(replacements...).Replace(customMessage)
 }
 
-func (v validatorImpl[T]) getLabel(fieldType reflect.StructField, parentProperty ...models.ValidatorProperty) string {
-	label := fieldType.Name
-	jsonTag := fieldType.Tag.Get("json")
-	if jsonTag != "" {
-		label = jsonTag
+// legacyIndexMessage formats a message written for earlier versions, which
+// passed the array indices from the outermost parent to fmt.Sprintf, e.g.
+// "bill %d is invalid". Messages with more %d verbs than indices are
+// returned unchanged.
+func legacyIndexMessage(message string, indices []int) string {
+	verbs := strings.Count(message, "%d") - strings.Count(message, "%%d")
+	if verbs <= 0 || verbs > len(indices) {
+		return message
+	}
+	args := make([]interface{}, verbs)
+	for i := range args {
+		args[i] = indices[i]
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// jsonName returns the name a field is serialised under, ignoring options
+// such as omitempty.
+func jsonName(fieldName string, jsonTag string) string {
+	name, _, _ := strings.Cut(jsonTag, ",")
+	if name == "" || name == "-" {
+		return fieldName
+	}
+	return name
+}
+
+// fieldLabel is getLabel extended with the element key of values reached
+// through dive, e.g. additionalInfo.tags.3.
+func (v validatorImpl[T]) fieldLabel(customValidator models.CustomValidator, parentProperty ...models.ValidatorProperty) string {
+	label := v.getLabel(customValidator.FieldType, parentProperty...)
+	if customValidator.Element {
+		label += "." + customValidator.ElementKey
 	}
+	return label
+}
+
+func (v validatorImpl[T]) getLabel(fieldType reflect.StructField, parentProperty ...models.ValidatorProperty) string {
+	label := jsonName(fieldType.Name, fieldType.Tag.Get("json"))
 	labelWithParent := ""
 	if len(parentProperty) > 0 {
 		for _, labelParent := range parentProperty {
-			if labelParent.JsonName != "" {
-				labelWithParent += labelParent.JsonName
+			if labelParent.JsonName != "" || labelParent.FieldName != "" {
+				labelWithParent += jsonName(labelParent.FieldName, labelParent.JsonName)
 				labelWithParent += "."
 				if labelParent.Array {
 					labelWithParent += fmt.Sprintf("%d.", labelParent.IdxArray)
 				}
-			} else if labelParent.FieldName != "" {
-				labelWithParent += labelParent.FieldName
-				labelWithParent += "."
-				if labelParent.Array {
-					labelWithParent += fmt.Sprintf("%d.", labelParent.IdxArray)
+				if labelParent.Map {
+					labelWithParent += labelParent.MapKey + "."
 				}
 			}
 
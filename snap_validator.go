@@ -1,28 +1,173 @@
 package snap_validator
 
 import (
+	"context"
+	"github.com/apelweb15/snap-validator/internal/models"
 	"github.com/apelweb15/snap-validator/internal/validator"
+	"github.com/apelweb15/snap-validator/snap_validator_errors"
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"github.com/apelweb15/snap-validator/snapmodels"
 )
 
+// RuleContext describes the field a custom rule is validating.
+type RuleContext = models.RuleContext
+
+// RuleFunc is the signature of a custom rule. See RegisterRule.
+type RuleFunc = models.RuleFunc
+
 var snapValidator *SnapValidator
 
 func init() {
 	snapValidator = New()
 }
 
-func New() *SnapValidator {
+// Option configures a SnapValidator created with New.
+type Option func(*SnapValidator)
+
+// WithCollectAllErrors makes ValidateStruct walk the whole struct instead of
+// stopping at the first failing field. Failures are returned as
+// snap_validator_errors.ValidationErrors; use Primary for the response code.
+func WithCollectAllErrors() Option {
+	return func(v *SnapValidator) {
+		v.options.CollectAll = true
+	}
+}
+
+// WithCustomMessage replaces the default message of rule for the field at
+// path. The path is either the Go field name (CustomerNo), the Go field path
+// (BillDetails.*.BillCode) or the JSON path (billDetails.*.billCode), using
+// "*" in place of array indices.
+//
+// The message may contain the placeholders {label} (JSON path of the field
+// with indices), {param} (rule parameter, e.g. 8 for max_length:8), {index}
+// (innermost array index) and {index0}, {index1}, ... (array indices starting
+// from the outermost parent).
+//
+// Messages in the fmt form of earlier versions, e.g. "bill %d is invalid",
+// still receive the array indices from the outermost parent, one per %d.
+// New messages should use the placeholders instead.
+func WithCustomMessage(path string, rule string, message string) Option {
+	return func(v *SnapValidator) {
+		if v.options.CustomMessages == nil {
+			v.options.CustomMessages = map[string]string{}
+		}
+		v.options.CustomMessages[path+":"+rule] = message
+	}
+}
+
+// WithCustomMessages registers several messages at once, keyed as "path:rule".
+// See WithCustomMessage for the path format and placeholders.
+func WithCustomMessages(messages map[string]string) Option {
+	return func(v *SnapValidator) {
+		if v.options.CustomMessages == nil {
+			v.options.CustomMessages = map[string]string{}
+		}
+		for key, message := range messages {
+			v.options.CustomMessages[key] = message
+		}
+	}
+}
+
+// WithLocale sets the default language of error messages, e.g.
+// snap_validator_errors.LocaleIndonesian. ValidateStructContext can override
+// it per call.
+func WithLocale(locale snap_validator_errors.Locale) Option {
+	return func(v *SnapValidator) {
+		v.options.Locale = locale
+	}
+}
+
+// WithMessages replaces the message catalog, e.g. one created with
+// snap_validator_errors.NewMessages and extended with LoadFile.
+func WithMessages(messages *snap_validator_errors.Messages) Option {
+	return func(v *SnapValidator) {
+		v.options.Messages = messages
+	}
+}
+
+func New(opts ...Option) *SnapValidator {
 	v := new(SnapValidator)
-	v.validator = validator.NewService[any]()
+	for _, opt := range opts {
+		opt(v)
+	}
+	v.validator = validator.NewWithOptions[any](v.options)
 	return v
 }
 
 type SnapValidator struct {
 	validator validator.Validator[any]
+	options   validator.Options
 }
 
 func ValidateStruct(data interface{}, serviceCode string) error {
 	return snapValidator.ValidateStruct(data, serviceCode)
 }
+
+// ValidateStruct validates data and reports failures with SNAP codes for
+// serviceCode, e.g. "25". A malformed service code is returned as
+// snap_validator_services.ErrInvalidServiceCode; earlier versions accepted
+// it and built malformed response codes.
+// An empty serviceCode is still accepted for compatibility and reports five
+// digit codes without a service code, e.g. 40002; that use is deprecated in
+// favour of ValidateStructService or ValidateModel.
 func (v *SnapValidator) ValidateStruct(data interface{}, serviceCode string) error {
-	return v.validator.ValidateStructSnapServiceCode(data, serviceCode)
+	if serviceCode == "" {
+		return v.validator.ValidateStructSnapServiceCode(data, serviceCode)
+	}
+	return v.ValidateStructService(data, snap_validator_services.ServiceCode(serviceCode))
+}
+
+// ValidateStructService validates data on the default validator.
+func ValidateStructService(data interface{}, serviceCode snap_validator_services.ServiceCode) error {
+	return snapValidator.ValidateStructService(data, serviceCode)
+}
+
+// ValidateStructService is ValidateStruct with a typed service code, e.g.
+// snap_validator_services.VirtualAccountPayment.
+func (v *SnapValidator) ValidateStructService(data interface{}, serviceCode snap_validator_services.ServiceCode) error {
+	if err := serviceCode.Validate(); err != nil {
+		return err
+	}
+	return v.validator.ValidateStructSnapServiceCode(data, string(serviceCode))
+}
+
+// ValidateStructContext is ValidateStruct with the message locale taken from
+// ctx when set with snap_validator_errors.ContextWithLocale.
+func (v *SnapValidator) ValidateStructContext(ctx context.Context, data interface{}, serviceCode snap_validator_services.ServiceCode) error {
+	if err := serviceCode.Validate(); err != nil {
+		return err
+	}
+	locale, _ := snap_validator_errors.LocaleFromContext(ctx)
+	return v.validator.ValidateStructSnapLocale(data, string(serviceCode), locale)
+}
+
+// ValidateModel validates data on the default validator.
+func ValidateModel(data snapmodels.Model) error {
+	return snapValidator.ValidateModel(data)
+}
+
+// ValidateModel is ValidateStruct with the service code taken from data, e.g.
+// a va.PaymentRequest is validated for service 25.
+func (v *SnapValidator) ValidateModel(data snapmodels.Model) error {
+	return v.ValidateStructService(data, data.ServiceCode())
+}
+
+// ValidateModelContext is ValidateStructContext with the service code taken
+// from data.
+func (v *SnapValidator) ValidateModelContext(ctx context.Context, data snapmodels.Model) error {
+	return v.ValidateStructContext(ctx, data, data.ServiceCode())
+}
+
+// RegisterRule registers a custom rule on the default validator.
+func RegisterRule(name string, rule RuleFunc) error {
+	return snapValidator.RegisterRule(name, rule)
+}
+
+// RegisterRule makes name usable in snapValidator tags, e.g.
+// `snapValidator:"required|va_prefix:12345"`. The rule receives the field
+// value and its parsed parameters; a failing rule is reported with the same
+// SNAP code format and custom messages as the built-in rules. Built-in rule
+// names cannot be overridden.
+func (v *SnapValidator) RegisterRule(name string, rule RuleFunc) error {
+	return v.validator.RegisterRule(name, rule)
 }
@@ -0,0 +1,138 @@
+package snap_validator_errors
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Category groups SNAP response cases by who has to act on them.
+type Category string
+
+const (
+	// CategorySuccess covers the 2xx cases.
+	CategorySuccess Category = "success"
+	// CategoryBusiness covers the 4xx cases: the request or the customer
+	// state is rejected and retrying the same request will not help.
+	CategoryBusiness Category = "business"
+	// CategorySystem covers the 5xx cases raised by the provider or a
+	// downstream system.
+	CategorySystem Category = "system"
+)
+
+// ResponseCase describes one SNAP case code, e.g. 400 01 Invalid Field
+// Format. The wire responseCode is HTTP status + service code + case code.
+type ResponseCase struct {
+	// Code is the HTTP status followed by the case code, e.g. "40001".
+	Code       string
+	HTTPStatus int
+	// CaseCode is the two digit case number, e.g. "01".
+	CaseCode string
+	Message  string
+	Category Category
+}
+
+var catalog = []ResponseCase{
+	{Code: "20000", HTTPStatus: 200, CaseCode: "00", Message: "Successful", Category: CategorySuccess},
+	{Code: "20200", HTTPStatus: 202, CaseCode: "00", Message: "Request In Progress", Category: CategorySuccess},
+	{Code: "40000", HTTPStatus: 400, CaseCode: "00", Message: "Bad Request", Category: CategoryBusiness},
+	{Code: "40001", HTTPStatus: 400, CaseCode: "01", Message: "Invalid Field Format", Category: CategoryBusiness},
+	{Code: "40002", HTTPStatus: 400, CaseCode: "02", Message: "Missing Mandatory Field", Category: CategoryBusiness},
+	{Code: "40100", HTTPStatus: 401, CaseCode: "00", Message: "Unauthorized", Category: CategoryBusiness},
+	{Code: "40101", HTTPStatus: 401, CaseCode: "01", Message: "Invalid Token (B2B)", Category: CategoryBusiness},
+	{Code: "40102", HTTPStatus: 401, CaseCode: "02", Message: "Invalid Customer Token", Category: CategoryBusiness},
+	{Code: "40103", HTTPStatus: 401, CaseCode: "03", Message: "Token Not Found (B2B)", Category: CategoryBusiness},
+	{Code: "40104", HTTPStatus: 401, CaseCode: "04", Message: "Customer Token Not Found", Category: CategoryBusiness},
+	{Code: "40300", HTTPStatus: 403, CaseCode: "00", Message: "Transaction Expired", Category: CategoryBusiness},
+	{Code: "40301", HTTPStatus: 403, CaseCode: "01", Message: "Feature Not Allowed", Category: CategoryBusiness},
+	{Code: "40302", HTTPStatus: 403, CaseCode: "02", Message: "Exceeds Transaction Amount Limit", Category: CategoryBusiness},
+	{Code: "40303", HTTPStatus: 403, CaseCode: "03", Message: "Suspected Fraud", Category: CategoryBusiness},
+	{Code: "40304", HTTPStatus: 403, CaseCode: "04", Message: "Activity Count Limit Exceeded", Category: CategoryBusiness},
+	{Code: "40305", HTTPStatus: 403, CaseCode: "05", Message: "Do Not Honor", Category: CategoryBusiness},
+	{Code: "40306", HTTPStatus: 403, CaseCode: "06", Message: "Feature Not Allowed At This Time", Category: CategoryBusiness},
+	{Code: "40307", HTTPStatus: 403, CaseCode: "07", Message: "Card Blocked", Category: CategoryBusiness},
+	{Code: "40308", HTTPStatus: 403, CaseCode: "08", Message: "Card Expired", Category: CategoryBusiness},
+	{Code: "40309", HTTPStatus: 403, CaseCode: "09", Message: "Dormant Account", Category: CategoryBusiness},
+	{Code: "40310", HTTPStatus: 403, CaseCode: "10", Message: "Need To Set Token Limit", Category: CategoryBusiness},
+	{Code: "40311", HTTPStatus: 403, CaseCode: "11", Message: "OTP Blocked", Category: CategoryBusiness},
+	{Code: "40312", HTTPStatus: 403, CaseCode: "12", Message: "OTP Lifetime Expired", Category: CategoryBusiness},
+	{Code: "40313", HTTPStatus: 403, CaseCode: "13", Message: "OTP Sent To Cardholer", Category: CategoryBusiness},
+	{Code: "40314", HTTPStatus: 403, CaseCode: "14", Message: "Insufficient Funds", Category: CategoryBusiness},
+	{Code: "40315", HTTPStatus: 403, CaseCode: "15", Message: "Transaction Not Permitted", Category: CategoryBusiness},
+	{Code: "40316", HTTPStatus: 403, CaseCode: "16", Message: "Suspend Transaction", Category: CategoryBusiness},
+	{Code: "40317", HTTPStatus: 403, CaseCode: "17", Message: "Token Limit Exceeded", Category: CategoryBusiness},
+	{Code: "40318", HTTPStatus: 403, CaseCode: "18", Message: "Inactive Card/Account/Customer", Category: CategoryBusiness},
+	{Code: "40319", HTTPStatus: 403, CaseCode: "19", Message: "Merchant Blacklisted", Category: CategoryBusiness},
+	{Code: "40320", HTTPStatus: 403, CaseCode: "20", Message: "Merchant Limit Exceed", Category: CategoryBusiness},
+	{Code: "40321", HTTPStatus: 403, CaseCode: "21", Message: "Set Limit Not Allowed", Category: CategoryBusiness},
+	{Code: "40322", HTTPStatus: 403, CaseCode: "22", Message: "Token Limit Invalid", Category: CategoryBusiness},
+	{Code: "40323", HTTPStatus: 403, CaseCode: "23", Message: "Account Limit Exceed", Category: CategoryBusiness},
+	{Code: "40400", HTTPStatus: 404, CaseCode: "00", Message: "Invalid Transaction Status", Category: CategoryBusiness},
+	{Code: "40401", HTTPStatus: 404, CaseCode: "01", Message: "Transaction Not Found", Category: CategoryBusiness},
+	{Code: "40402", HTTPStatus: 404, CaseCode: "02", Message: "Invalid Routing", Category: CategoryBusiness},
+	{Code: "40403", HTTPStatus: 404, CaseCode: "03", Message: "Bank Not Supported By Switch", Category: CategoryBusiness},
+	{Code: "40404", HTTPStatus: 404, CaseCode: "04", Message: "Transaction Cancelled", Category: CategoryBusiness},
+	{Code: "40405", HTTPStatus: 404, CaseCode: "05", Message: "Merchant Is Not Registered For Card Registration Services", Category: CategoryBusiness},
+	{Code: "40406", HTTPStatus: 404, CaseCode: "06", Message: "Need To Request OTP", Category: CategoryBusiness},
+	{Code: "40407", HTTPStatus: 404, CaseCode: "07", Message: "Journey Not Found", Category: CategoryBusiness},
+	{Code: "40408", HTTPStatus: 404, CaseCode: "08", Message: "Invalid Merchant", Category: CategoryBusiness},
+	{Code: "40409", HTTPStatus: 404, CaseCode: "09", Message: "No Issuer", Category: CategoryBusiness},
+	{Code: "40410", HTTPStatus: 404, CaseCode: "10", Message: "Invalid API Transition", Category: CategoryBusiness},
+	{Code: "40411", HTTPStatus: 404, CaseCode: "11", Message: "Invalid Card/Account/Customer/Virtual Account", Category: CategoryBusiness},
+	{Code: "40412", HTTPStatus: 404, CaseCode: "12", Message: "Invalid Bill/Virtual Account", Category: CategoryBusiness},
+	{Code: "40413", HTTPStatus: 404, CaseCode: "13", Message: "Invalid Amount", Category: CategoryBusiness},
+	{Code: "40414", HTTPStatus: 404, CaseCode: "14", Message: "Paid Bill", Category: CategoryBusiness},
+	{Code: "40415", HTTPStatus: 404, CaseCode: "15", Message: "Invalid OTP", Category: CategoryBusiness},
+	{Code: "40416", HTTPStatus: 404, CaseCode: "16", Message: "Partner Not Found", Category: CategoryBusiness},
+	{Code: "40417", HTTPStatus: 404, CaseCode: "17", Message: "Invalid Terminal", Category: CategoryBusiness},
+	{Code: "40418", HTTPStatus: 404, CaseCode: "18", Message: "Inconsistent Request", Category: CategoryBusiness},
+	{Code: "40419", HTTPStatus: 404, CaseCode: "19", Message: "Invalid Bill/Virtual Account", Category: CategoryBusiness},
+	{Code: "40500", HTTPStatus: 405, CaseCode: "00", Message: "Requested Function Is Not Supported", Category: CategoryBusiness},
+	{Code: "40501", HTTPStatus: 405, CaseCode: "01", Message: "Requested Opearation Is Not Allowed", Category: CategoryBusiness},
+	{Code: "40900", HTTPStatus: 409, CaseCode: "00", Message: "Conflict", Category: CategoryBusiness},
+	{Code: "40901", HTTPStatus: 409, CaseCode: "01", Message: "Duplicate partnerReferenceNo", Category: CategoryBusiness},
+	{Code: "42900", HTTPStatus: 429, CaseCode: "00", Message: "Too Many Requests", Category: CategoryBusiness},
+	{Code: "50000", HTTPStatus: 500, CaseCode: "00", Message: "General Error", Category: CategorySystem},
+	{Code: "50001", HTTPStatus: 500, CaseCode: "01", Message: "Internal Server Error", Category: CategorySystem},
+	{Code: "50002", HTTPStatus: 500, CaseCode: "02", Message: "External Server Error", Category: CategorySystem},
+	{Code: "50400", HTTPStatus: 504, CaseCode: "00", Message: "Timeout", Category: CategorySystem},
+}
+
+var catalogByCode = func() map[string]ResponseCase {
+	byCode := make(map[string]ResponseCase, len(catalog))
+	for _, responseCase := range catalog {
+		byCode[responseCase.Code] = responseCase
+	}
+	return byCode
+}()
+
+// LookupCase returns the catalog entry of a five digit code such as "40001".
+func LookupCase(code string) (ResponseCase, bool) {
+	responseCase, ok := catalogByCode[code]
+	return responseCase, ok
+}
+
+// Catalog returns every known SNAP case, ordered by code.
+func Catalog() []ResponseCase {
+	cases := make([]ResponseCase, len(catalog))
+	copy(cases, catalog)
+	return cases
+}
+
+// HTTPStatus returns the HTTP status to answer with, taken from the first
+// three digits of Code. Codes that do not start with a valid status map to
+// 500 Internal Server Error.
+func (e *ErrorValidation) HTTPStatus() int {
+	return httpStatusOf(e.Code)
+}
+
+func httpStatusOf(code string) int {
+	if responseCase, ok := LookupCase(code); ok {
+		return responseCase.HTTPStatus
+	}
+	if len(code) >= 3 {
+		if status, err := strconv.Atoi(code[:3]); err == nil && http.StatusText(status) != "" {
+			return status
+		}
+	}
+	return http.StatusInternalServerError
+}
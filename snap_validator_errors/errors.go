@@ -1,26 +1,102 @@
 package snap_validator_errors
 
+import (
+	"context"
+	"strings"
+)
+
 type ErrorValidation struct {
 	Code      string
 	SnapCode  string
 	Message   string
 	FieldName string
+	// Path is the dotted JSON path of the field, e.g. billDetails.0.billCode.
+	Path string
+	// Rule is the failing rule, e.g. max_length, and Params its parameters.
+	Rule   string
+	Params []string
+	// Value is the offending value, masked for fields tagged snapMask:"true".
+	// It is empty for objects, lists and absent fields.
+	Value string
+	// Detail explains the failure, e.g. "length must be at most 8". Unlike
+	// Message it is not part of the SNAP response body.
+	Detail string
+	// Err is the underlying cause, if any. See Unwrap.
+	Err error
 }
 
 func (e *ErrorValidation) Error() string {
 	return e.Message
 }
-func NewErrorSnap(code string, label string, fieldName string, serviceCode string) *ErrorValidation {
-	snapCode := code
-	if len(code) > 4 {
-		snapCode = code[:3] + serviceCode + code[3:5]
+
+// ValidationErrors is returned when the validator runs in collect-all mode.
+// Errors keep the order in which the struct was walked, so the first one is
+// the error a fail-fast validator would have returned.
+type ValidationErrors []*ErrorValidation
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Primary returns the error that should drive the SNAP response.
+func (e ValidationErrors) Primary() *ErrorValidation {
+	if len(e) == 0 {
+		return nil
+	}
+	return e[0]
+}
+
+// SnapCode returns the SNAP code of the primary error.
+func (e ValidationErrors) SnapCode() string {
+	if primary := e.Primary(); primary != nil {
+		return primary.SnapCode
 	}
+	return ""
+}
+
+// NewErrorSnap builds the error of the field at label, e.g. customerNo, for
+// serviceCode. The label is used as Path as well.
+func NewErrorSnap(code string, label string, fieldName string, serviceCode string) *ErrorValidation {
 	return &ErrorValidation{
 		Code:      code,
-		SnapCode:  snapCode,
+		SnapCode:  SnapCode(code, serviceCode),
 		Message:   GetSnapMessage(code) + " " + label,
 		FieldName: fieldName,
+		Path:      label,
+	}
+}
+
+// NewErrorSnapLocale builds the error of the header or field at label, e.g.
+// X-PARTNER-ID, for serviceCode with the message in the locale of ctx (see
+// ContextWithLocale). The label is appended to the message unless empty.
+// When rule is set, Detail is the catalog entry "detail.<rule>" with params.
+func NewErrorSnapLocale(ctx context.Context, code string, label string, serviceCode string, rule string, params []string, cause error) *ErrorValidation {
+	locale, _ := LocaleFromContext(ctx)
+	errorRes := NewErrorSnap(code, label, label, serviceCode)
+	errorRes.Message = GetSnapMessageLocale(code, locale)
+	if label != "" {
+		errorRes.Message += " " + label
+	}
+	if rule != "" {
+		errorRes.Rule = rule
+		errorRes.Params = params
+		errorRes.Detail = defaultMessages.RuleDetail(locale, rule, params)
 	}
+	errorRes.Err = cause
+	return errorRes
+}
+
+// SnapCode splices the service code into a five digit code: 40001 for
+// service 25 becomes 4002501. Shorter codes are returned unchanged.
+func SnapCode(code string, serviceCode string) string {
+	if len(code) > 4 {
+		return code[:3] + serviceCode + code[3:5]
+	}
+	return code
 }
 
 func NewError(code string, fieldName string) *ErrorValidation {
@@ -32,139 +108,11 @@ func NewError(code string, fieldName string) *ErrorValidation {
 	}
 }
 
+// GetSnapMessage returns the catalog message of a five digit code, or
+// "General Error" for unknown codes.
 func GetSnapMessage(code string) string {
-	message := ""
-	switch code {
-	case "20000":
-		message = "Successful"
-	case "20200":
-		message = "Request In Progress"
-	case "40000":
-		message = "Bad Request"
-	case "40001":
-		message = "Invalid Field Format"
-	case "40002":
-		message = "Missing Mandatory Field"
-	case "40100":
-		message = "Unauthorized"
-	case "40101":
-		message = "Invalid Token (B2B)"
-	case "40102":
-		message = "Invalid Customer Token"
-	case "40103":
-		message = "Token Not Found (B2B)"
-	case "40104":
-		message = "Customer Token Not Found"
-	case "40300":
-		message = "Transaction Expired"
-	case "40301":
-		message = "Feature Not Allowed"
-	case "40302":
-		message = "Exceeds Transaction Amount Limit"
-	case "40303":
-		message = "Suspected Fraud"
-	case "40304":
-		message = "Activity Count Limit Exceeded"
-	case "40305":
-		message = "Do Not Honor"
-	case "40306":
-		message = "Feature Not Allowed At This Time"
-	case "40307":
-		message = "Card Blocked"
-	case "40308":
-		message = "Card Expired"
-	case "40309":
-		message = "Dormant Account"
-	case "40310":
-		message = "Need To Set Token Limit"
-	case "40311":
-		message = "OTP Blocked"
-	case "40312":
-		message = "OTP Lifetime Expired"
-	case "40313":
-		message = "OTP Sent To Cardholer"
-	case "40314":
-		message = "Insufficient Funds"
-	case "40315":
-		message = "Transaction Not Permitted"
-	case "40316":
-		message = "Suspend Transaction"
-	case "40317":
-		message = "Token Limit Exceeded"
-	case "40318":
-		message = "Inactive Card/Account/Customer"
-	case "40319":
-		message = "Merchant Blacklisted"
-	case "40320":
-		message = "Merchant Limit Exceed"
-	case "40321":
-		message = "Set Limit Not Allowed"
-	case "40322":
-		message = "Token Limit Invalid"
-	case "40323":
-		message = "Account Limit Exceed"
-	case "40400":
-		message = "Invalid Transaction Status"
-	case "40401":
-		message = "Transaction Not Found"
-	case "40402":
-		message = "Invalid Routing"
-	case "40403":
-		message = "Bank Not Supported By Switch"
-	case "40404":
-		message = "Transaction Cancelled"
-	case "40405":
-		message = "Merchant Is Not Registered For Card Registration Services"
-	case "40406":
-		message = "Need To Request OTP"
-	case "40407":
-		message = "Journey Not Found"
-	case "40408":
-		message = "Invalid Merchant"
-	case "40409":
-		message = "No Issuer"
-	case "40410":
-		message = "Invalid API Transition"
-	case "40411":
-		message = "Invalid Card/Account/Customer/Virtual Account"
-	case "40412":
-		message = "Invalid Bill/Virtual Account"
-	case "40413":
-		message = "Invalid Amount"
-	case "40414":
-		message = "Paid Bill"
-	case "40415":
-		message = "Invalid OTP"
-	case "40416":
-		message = "Partner Not Found"
-	case "40417":
-		message = "Invalid Terminal"
-	case "40418":
-		message = "Inconsistent Request"
-	case "40419":
-		message = "Invalid Bill/Virtual Account"
-	case "40500":
-		message = "Requested Function Is Not Supported"
-	case "40501":
-		message = "Requested Opearation Is Not Allowed"
-	case "40900":
-		message = "Conflict"
-	case "40901":
-		message = "Duplicate partnerReferenceNo"
-	case "42900":
-		message = "Too Many Requests"
-	case "50000":
-		message = "General Error"
-	case "50001":
-		message = "Internal Server Error"
-	case "50002":
-		message = "External Server Error"
-	case "50400":
-		message = "Timeout"
-
-	default:
-		message = "General Error"
+	if responseCase, ok := LookupCase(code); ok {
+		return responseCase.Message
 	}
-	return message
-
+	return "General Error"
 }
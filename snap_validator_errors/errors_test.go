@@ -0,0 +1,139 @@
+package snap_validator_errors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCatalog(t *testing.T) {
+	responseCase, ok := LookupCase("40002")
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, responseCase.HTTPStatus)
+	assert.Equal(t, "02", responseCase.CaseCode)
+	assert.Equal(t, "Missing Mandatory Field", responseCase.Message)
+	assert.Equal(t, CategoryBusiness, responseCase.Category)
+
+	_, ok = LookupCase("49999")
+	assert.False(t, ok)
+	assert.Equal(t, "General Error", GetSnapMessage("49999"))
+
+	seen := map[string]bool{}
+	for _, responseCase := range Catalog() {
+		assert.False(t, seen[responseCase.Code], responseCase.Code)
+		seen[responseCase.Code] = true
+		assert.Equal(t, responseCase.Message, GetSnapMessage(responseCase.Code))
+	}
+	assert.Equal(t, CategorySystem, catalogByCode["50400"].Category)
+}
+
+func TestHTTPStatus(t *testing.T) {
+	assert.Equal(t, http.StatusBadRequest, NewErrorSnap("40001", "customerNo", "CustomerNo", "25").HTTPStatus())
+	assert.Equal(t, http.StatusUnauthorized, NewError("40101", "").HTTPStatus())
+	assert.Equal(t, http.StatusTooManyRequests, NewError("42900", "").HTTPStatus())
+	assert.Equal(t, http.StatusInternalServerError, NewError("500000", "MaxLength").HTTPStatus())
+	assert.Equal(t, http.StatusInternalServerError, (&ErrorValidation{Code: "x"}).HTTPStatus())
+}
+
+func TestResponse(t *testing.T) {
+	errorRes := NewErrorSnap("40002", "customerNo", "CustomerNo", "25")
+	recorder := httptest.NewRecorder()
+	err := NewResponse(errorRes).Write(recorder)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"responseCode":"4002502","responseMessage":"Missing Mandatory Field customerNo"}`, recorder.Body.String())
+
+	response := ResponseFromError(ValidationErrors{errorRes, NewErrorSnap("40001", "trxId", "TrxId", "25")}, "25").
+		WithAdditionalInfo("field", errorRes.Path)
+	body, _ := json.Marshal(response)
+	assert.JSONEq(t, `{"responseCode":"4002502","responseMessage":"Missing Mandatory Field customerNo","additionalInfo":{"field":"customerNo"}}`, string(body))
+
+	response = ResponseFromError(errors.New("db down"), "25")
+	assert.Equal(t, http.StatusInternalServerError, response.HTTPStatus)
+	assert.Equal(t, "5002500", response.ResponseCode)
+	assert.Equal(t, "General Error", response.ResponseMessage)
+}
+
+func TestNewErrorSnapLocale(t *testing.T) {
+	cause := errors.New("cause")
+	ctx := ContextWithLocale(context.Background(), LocaleIndonesian)
+	errorRes := NewErrorSnapLocale(ctx, "40002", "X-PARTNER-ID", "25", "required", nil, cause)
+	assert.Equal(t, "4002502", errorRes.SnapCode)
+	assert.Equal(t, "X-PARTNER-ID", errorRes.Path)
+	assert.Equal(t, "required", errorRes.Rule)
+	assert.Equal(t, "wajib diisi", errorRes.Detail)
+	assert.True(t, errors.Is(errorRes, cause))
+
+	errorRes = NewErrorSnapLocale(context.Background(), "40101", "", "73", "", nil, nil)
+	assert.Equal(t, "Invalid Token (B2B)", errorRes.Message)
+	assert.Empty(t, errorRes.Detail)
+}
+
+func TestParseResponseCode(t *testing.T) {
+	parsed, err := ParseResponseCode("4012401")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, parsed.HTTPStatus)
+	assert.Equal(t, "24", string(parsed.ServiceCode))
+	assert.Equal(t, "01", parsed.CaseCode)
+	assert.Equal(t, "40101", parsed.Code)
+	assert.Equal(t, "Invalid Token (B2B)", parsed.Message)
+	assert.True(t, parsed.Known)
+	assert.Equal(t, OutcomeRetryable, parsed.Outcome)
+	assert.Equal(t, "4012401", parsed.String())
+
+	outcomes := map[string]Outcome{
+		"2002500": OutcomeSuccess,
+		"2021700": OutcomePending,
+		"5041800": OutcomePending,
+		"4291700": OutcomeRetryable,
+		"5001801": OutcomeRetryable,
+		"4002502": OutcomeFatal,
+		"4042514": OutcomeFatal,
+	}
+	for code, outcome := range outcomes {
+		parsed, err := ParseResponseCode(code)
+		assert.NoError(t, err, code)
+		assert.Equal(t, outcome, parsed.Outcome, code)
+	}
+
+	parsed, err = ParseResponseCode("4032499")
+	assert.NoError(t, err)
+	assert.False(t, parsed.Known)
+	assert.Equal(t, "Forbidden", parsed.Message)
+	assert.Equal(t, CategoryBusiness, parsed.Category)
+
+	for _, code := range []string{"", "40124", "40124011", "4a12401", "9992401"} {
+		_, err := ParseResponseCode(code)
+		assert.Error(t, err, code)
+	}
+}
+
+func TestMessagesLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messages.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"id": {"rule.after_time_now": "harus setelah sekarang"}}`), 0o600))
+
+	messages := NewMessages()
+	assert.NoError(t, messages.LoadFile(path))
+	assert.Equal(t, "harus setelah sekarang", messages.RuleMessage(LocaleIndonesian, "after_time_now", ""))
+	assert.Equal(t, "harus sama dengan totalAmount", messages.RuleMessage(LocaleIndonesian, "eq_field", "totalAmount"))
+	assert.Equal(t, "Invalid Token (B2B)", messages.SnapMessage("fr", "40101"))
+	assert.Equal(t, "Kesalahan Umum", messages.SnapMessage(LocaleIndonesian, "49999"))
+	assert.Equal(t, "Saldo Tidak Cukup", GetSnapMessageLocale("40314", LocaleIndonesian))
+	assert.Error(t, messages.LoadFile(filepath.Join(t.TempDir(), "missing.json")))
+}
+
+func TestSentinels(t *testing.T) {
+	assert.True(t, ErrInvalidToken.Matches("4017301"))
+	assert.True(t, ErrUnauthorized.Matches("40101"))
+	assert.False(t, ErrInvalidToken.Matches("4017303"))
+	assert.True(t, ErrServerError.Matches("50400"))
+	assert.True(t, errors.Is(NewError("40103", ""), ErrTokenNotFound))
+	assert.True(t, errors.Is(NewError("40103", ""), NewError("40103", "token")))
+}
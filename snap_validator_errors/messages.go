@@ -0,0 +1,311 @@
+package snap_validator_errors
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Locale selects the language of validation messages.
+type Locale string
+
+const (
+	LocaleEnglish    Locale = "en"
+	LocaleIndonesian Locale = "id"
+)
+
+// Messages is a message catalog per locale. Keys are five digit SNAP codes
+// ("40002") for the code message and "rule.<name>" for the explanation
+// appended by some rules ("rule.after_time_now"), where {field} is replaced
+// by the label of the compared field. Lookups fall back to English.
+type Messages struct {
+	mu       sync.RWMutex
+	messages map[Locale]map[string]string
+}
+
+var defaultMessages = NewMessages()
+
+// DefaultMessages returns the catalog used when no other is configured.
+// Translations loaded into it apply to every validator.
+func DefaultMessages() *Messages {
+	return defaultMessages
+}
+
+// NewMessages returns a catalog holding the built-in English and Indonesian
+// messages.
+func NewMessages() *Messages {
+	m := &Messages{messages: map[Locale]map[string]string{}}
+	for _, responseCase := range catalog {
+		m.Set(LocaleEnglish, responseCase.Code, responseCase.Message)
+	}
+	for key, message := range englishRuleMessages {
+		m.Set(LocaleEnglish, key, message)
+	}
+	for key, message := range indonesianMessages {
+		m.Set(LocaleIndonesian, key, message)
+	}
+	return m
+}
+
+// Set adds or overrides a translation.
+func (m *Messages) Set(locale Locale, key string, message string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.messages[locale] == nil {
+		m.messages[locale] = map[string]string{}
+	}
+	m.messages[locale][key] = message
+}
+
+// Get returns the message for key in locale, falling back to English and
+// then to "".
+func (m *Messages) Get(locale Locale, key string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if message, ok := m.messages[locale][key]; ok {
+		return message
+	}
+	return m.messages[LocaleEnglish][key]
+}
+
+// SnapMessage returns the message of a five digit SNAP code, or the General
+// Error message for unknown codes.
+func (m *Messages) SnapMessage(locale Locale, code string) string {
+	if message := m.Get(locale, code); message != "" {
+		return message
+	}
+	return m.Get(locale, "50000")
+}
+
+// RuleDetail returns the detail of a failing rule. {param} is replaced by
+// the parameters joined with ", ", {param0} by the first parameter, {rest} by
+// the remaining ones joined with " or " and {rule} by the rule name. Rules
+// without a detail message use "detail.default".
+func (m *Messages) RuleDetail(locale Locale, rule string, params []string) string {
+	detail := m.Get(locale, "detail."+rule)
+	if detail == "" {
+		detail = m.Get(locale, "detail.default")
+	}
+	param0, rest := "", ""
+	if len(params) > 0 {
+		param0 = params[0]
+		rest = strings.Join(params[1:], " or ")
+	}
+	return strings.NewReplacer(
+		"{param0}", param0,
+		"{param}", strings.Join(params, ", "),
+		"{rest}", rest,
+		"{rule}", rule,
+	).Replace(detail)
+}
+
+// RuleMessage returns the explanation of rule with {field} replaced.
+func (m *Messages) RuleMessage(locale Locale, rule string, field string) string {
+	return strings.ReplaceAll(m.Get(locale, "rule."+rule), "{field}", field)
+}
+
+// Load merges translations from JSON shaped as
+//
+//	{"id": {"40002": "Data Wajib Belum Diisi", "rule.after_time_now": "..."}}
+func (m *Messages) Load(r io.Reader) error {
+	var translations map[Locale]map[string]string
+	if err := json.NewDecoder(r).Decode(&translations); err != nil {
+		return err
+	}
+	for locale, messages := range translations {
+		for key, message := range messages {
+			m.Set(locale, key, message)
+		}
+	}
+	return nil
+}
+
+// LoadFile merges translations from a JSON file, see Load.
+func (m *Messages) LoadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return m.Load(file)
+}
+
+// GetSnapMessageLocale is GetSnapMessage in the given locale.
+func GetSnapMessageLocale(code string, locale Locale) string {
+	return defaultMessages.SnapMessage(locale, code)
+}
+
+type localeContextKey struct{}
+
+// ContextWithLocale returns a context selecting locale for validation calls
+// that take a context.
+func ContextWithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, locale)
+}
+
+// LocaleFromContext returns the locale set by ContextWithLocale.
+func LocaleFromContext(ctx context.Context) (Locale, bool) {
+	locale, ok := ctx.Value(localeContextKey{}).(Locale)
+	return locale, ok
+}
+
+var englishRuleMessages = map[string]string{
+	"rule.after_time_now": "must greater than now",
+	"rule.eq_field":       "must equal {field}",
+	"rule.gt_field":       "must be greater than {field}",
+	"rule.before_field":   "must be before {field}",
+	"rule.after_field":    "must be after {field}",
+
+	"detail.default":              "failed rule {rule}",
+	"detail.required":             "is required",
+	"detail.min_length":           "length must be at least {param}",
+	"detail.max_length":           "length must be at most {param}",
+	"detail.iso_date":             "must be an ISO-8601 date time with offset, e.g. 2024-12-31T23:59:59+07:00",
+	"detail.after_time_now":       "must be later than the current time",
+	"detail.alpha_numeric":        "must contain only letters and digits",
+	"detail.alpha_numeric_symbol": "must contain only letters, digits, dash and underscore",
+	"detail.numeric":              "must contain only digits",
+	"detail.string":               "contains characters that are not allowed",
+	"detail.amount":               "must be an amount with two zero decimals, e.g. 10000.00",
+	"detail.email":                "must be a valid email address",
+	"detail.url":                  "must be a valid URL",
+	"detail.in_data":              "must be one of {param}",
+	"detail.min_items":            "must have at least {param} items",
+	"detail.max_items":            "must have at most {param} items",
+	"detail.unique":               "must not contain duplicate items",
+	"detail.required_keys":        "is required",
+	"detail.required_if":          "is required when {param0} is {rest}",
+	"detail.required_with":        "is required when {param} is present",
+	"detail.required_without":     "is required when {param} is absent",
+	"detail.eq_field":             "must equal {param}",
+	"detail.gt_field":             "must be greater than {param}",
+	"detail.before_field":         "must be before {param}",
+	"detail.after_field":          "must be after {param}",
+	"detail.signature":            "signature does not match",
+	"detail.unknown_partner":      "partner is not registered",
+	"detail.invalid_key":          "registered key cannot be used",
+	"detail.invalid_body":         "body cannot be read as JSON",
+	"detail.body_too_large":       "body is larger than {param} bytes",
+	"detail.json_type":            "must not be a JSON {param}",
+	"detail.bearer":               "must be a Bearer token",
+	"detail.clock_skew":           "must be within {param} of the server time",
+	"detail.external_id":          "must be numeric with at most {param} digits",
+	"detail.partner_id":           "must be 1 to 36 letters, digits, dash or underscore",
+	"detail.channel_id":           "must be 5 digits",
+	"detail.client_key":           "must be 1 to 64 letters, digits, dash, underscore or dot",
+	"detail.key_lookup":           "key lookup failed",
+}
+
+var indonesianMessages = map[string]string{
+	"20000": "Berhasil",
+	"20200": "Permintaan Sedang Diproses",
+	"40000": "Permintaan Tidak Valid",
+	"40001": "Format Field Tidak Valid",
+	"40002": "Field Wajib Tidak Diisi",
+	"40100": "Tidak Terotorisasi",
+	"40101": "Token Tidak Valid (B2B)",
+	"40102": "Token Nasabah Tidak Valid",
+	"40103": "Token Tidak Ditemukan (B2B)",
+	"40104": "Token Nasabah Tidak Ditemukan",
+	"40300": "Transaksi Kedaluwarsa",
+	"40301": "Fitur Tidak Diizinkan",
+	"40302": "Melebihi Batas Nominal Transaksi",
+	"40303": "Terindikasi Penipuan",
+	"40304": "Melebihi Batas Jumlah Aktivitas",
+	"40305": "Transaksi Ditolak",
+	"40306": "Fitur Tidak Diizinkan Saat Ini",
+	"40307": "Kartu Diblokir",
+	"40308": "Kartu Kedaluwarsa",
+	"40309": "Rekening Tidak Aktif (Dormant)",
+	"40310": "Batas Token Perlu Diatur",
+	"40311": "OTP Diblokir",
+	"40312": "Masa Berlaku OTP Habis",
+	"40313": "OTP Dikirim Ke Pemegang Kartu",
+	"40314": "Saldo Tidak Cukup",
+	"40315": "Transaksi Tidak Diizinkan",
+	"40316": "Transaksi Ditangguhkan",
+	"40317": "Melebihi Batas Token",
+	"40318": "Kartu/Rekening/Nasabah Tidak Aktif",
+	"40319": "Merchant Masuk Daftar Hitam",
+	"40320": "Melebihi Batas Merchant",
+	"40321": "Pengaturan Batas Tidak Diizinkan",
+	"40322": "Batas Token Tidak Valid",
+	"40323": "Melebihi Batas Rekening",
+	"40400": "Status Transaksi Tidak Valid",
+	"40401": "Transaksi Tidak Ditemukan",
+	"40402": "Routing Tidak Valid",
+	"40403": "Bank Tidak Didukung Oleh Switch",
+	"40404": "Transaksi Dibatalkan",
+	"40405": "Merchant Tidak Terdaftar Untuk Layanan Registrasi Kartu",
+	"40406": "Perlu Meminta OTP",
+	"40407": "Journey Tidak Ditemukan",
+	"40408": "Merchant Tidak Valid",
+	"40409": "Issuer Tidak Tersedia",
+	"40410": "Transisi API Tidak Valid",
+	"40411": "Kartu/Rekening/Nasabah/Virtual Account Tidak Valid",
+	"40412": "Tagihan/Virtual Account Tidak Valid",
+	"40413": "Nominal Tidak Valid",
+	"40414": "Tagihan Sudah Dibayar",
+	"40415": "OTP Tidak Valid",
+	"40416": "Partner Tidak Ditemukan",
+	"40417": "Terminal Tidak Valid",
+	"40418": "Permintaan Tidak Konsisten",
+	"40419": "Tagihan/Virtual Account Tidak Valid",
+	"40500": "Fungsi Yang Diminta Tidak Didukung",
+	"40501": "Operasi Yang Diminta Tidak Diizinkan",
+	"40900": "Konflik",
+	"40901": "partnerReferenceNo Duplikat",
+	"42900": "Terlalu Banyak Permintaan",
+	"50000": "Kesalahan Umum",
+	"50001": "Kesalahan Server Internal",
+	"50002": "Kesalahan Server Eksternal",
+	"50400": "Waktu Habis",
+
+	"rule.after_time_now": "harus lebih dari waktu sekarang",
+	"rule.eq_field":       "harus sama dengan {field}",
+	"rule.gt_field":       "harus lebih besar dari {field}",
+	"rule.before_field":   "harus sebelum {field}",
+	"rule.after_field":    "harus setelah {field}",
+
+	"detail.default":              "gagal pada aturan {rule}",
+	"detail.required":             "wajib diisi",
+	"detail.min_length":           "panjang minimal {param} karakter",
+	"detail.max_length":           "panjang maksimal {param} karakter",
+	"detail.iso_date":             "harus berupa tanggal ISO-8601 dengan zona waktu, contoh 2024-12-31T23:59:59+07:00",
+	"detail.after_time_now":       "harus lebih dari waktu sekarang",
+	"detail.alpha_numeric":        "hanya boleh berisi huruf dan angka",
+	"detail.alpha_numeric_symbol": "hanya boleh berisi huruf, angka, tanda hubung dan garis bawah",
+	"detail.numeric":              "hanya boleh berisi angka",
+	"detail.string":               "berisi karakter yang tidak diizinkan",
+	"detail.amount":               "harus berupa nominal dengan dua desimal nol, contoh 10000.00",
+	"detail.email":                "harus berupa alamat email yang valid",
+	"detail.url":                  "harus berupa URL yang valid",
+	"detail.in_data":              "harus salah satu dari {param}",
+	"detail.min_items":            "minimal berisi {param} item",
+	"detail.max_items":            "maksimal berisi {param} item",
+	"detail.unique":               "tidak boleh berisi item yang sama",
+	"detail.required_keys":        "wajib diisi",
+	"detail.required_if":          "wajib diisi jika {param0} bernilai {rest}",
+	"detail.required_with":        "wajib diisi jika {param} diisi",
+	"detail.required_without":     "wajib diisi jika {param} tidak diisi",
+	"detail.eq_field":             "harus sama dengan {param}",
+	"detail.gt_field":             "harus lebih besar dari {param}",
+	"detail.before_field":         "harus sebelum {param}",
+	"detail.after_field":          "harus setelah {param}",
+	"detail.signature":            "tanda tangan tidak sesuai",
+	"detail.unknown_partner":      "partner tidak terdaftar",
+	"detail.invalid_key":          "kunci yang terdaftar tidak dapat digunakan",
+	"detail.invalid_body":         "body tidak dapat dibaca sebagai JSON",
+	"detail.body_too_large":       "body lebih besar dari {param} byte",
+	"detail.json_type":            "tidak boleh berupa {param} JSON",
+	"detail.bearer":               "harus berupa token Bearer",
+	"detail.clock_skew":           "harus dalam rentang {param} dari waktu server",
+	"detail.external_id":          "harus berupa angka dengan maksimal {param} digit",
+	"detail.partner_id":           "harus 1 sampai 36 huruf, angka, tanda hubung atau garis bawah",
+	"detail.channel_id":           "harus 5 digit angka",
+	"detail.client_key":           "harus 1 sampai 64 huruf, angka, tanda hubung, garis bawah atau titik",
+	"detail.key_lookup":           "gagal mencari kunci",
+}
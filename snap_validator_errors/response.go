@@ -0,0 +1,66 @@
+package snap_validator_errors
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// Response is the standard SNAP error body:
+//
+//	{"responseCode":"4002502","responseMessage":"Missing Mandatory Field customerNo"}
+type Response struct {
+	HTTPStatus      int                    `json:"-"`
+	ResponseCode    string                 `json:"responseCode"`
+	ResponseMessage string                 `json:"responseMessage"`
+	AdditionalInfo  map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+// NewResponse renders a validation error.
+func NewResponse(err *ErrorValidation) *Response {
+	return &Response{
+		HTTPStatus:      err.HTTPStatus(),
+		ResponseCode:    err.SnapCode,
+		ResponseMessage: err.Message,
+	}
+}
+
+// ResponseFromError renders any error returned by the validator. For
+// ValidationErrors the primary error is used. Errors that carry no SNAP code
+// are answered with 500 General Error for serviceCode.
+func ResponseFromError(err error, serviceCode string) *Response {
+	var validationErrors ValidationErrors
+	if errors.As(err, &validationErrors) && validationErrors.Primary() != nil {
+		return NewResponse(validationErrors.Primary())
+	}
+	var errorValidation *ErrorValidation
+	if errors.As(err, &errorValidation) {
+		return NewResponse(errorValidation)
+	}
+	return &Response{
+		HTTPStatus:      http.StatusInternalServerError,
+		ResponseCode:    SnapCode("50000", serviceCode),
+		ResponseMessage: GetSnapMessage("50000"),
+	}
+}
+
+// WithAdditionalInfo adds an entry to the additionalInfo object of the body.
+func (r *Response) WithAdditionalInfo(key string, value interface{}) *Response {
+	if r.AdditionalInfo == nil {
+		r.AdditionalInfo = map[string]interface{}{}
+	}
+	r.AdditionalInfo[key] = value
+	return r
+}
+
+// Write sends the response as JSON with its HTTP status.
+func (r *Response) Write(w http.ResponseWriter) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(r.HTTPStatus)
+	_, err = w.Write(body)
+	return err
+}
@@ -0,0 +1,103 @@
+package snap_validator_errors
+
+import (
+	"fmt"
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"net/http"
+	"strconv"
+)
+
+// Outcome tells a SNAP client what to do with a response.
+type Outcome string
+
+const (
+	// OutcomeSuccess means the request was processed.
+	OutcomeSuccess Outcome = "success"
+	// OutcomePending means the final state is not known yet (202 In
+	// Progress, 504 Timeout); check the transaction status before retrying.
+	OutcomePending Outcome = "pending"
+	// OutcomeRetryable means the same request may be sent again: 429, 5xx
+	// other than 504, and 40101/40103 once the B2B access token is renewed.
+	OutcomeRetryable Outcome = "retryable"
+	// OutcomeFatal means the request was rejected and must not be retried
+	// unchanged.
+	OutcomeFatal Outcome = "fatal"
+)
+
+// ResponseCode is a parsed seven digit SNAP responseCode, e.g. 4012401 is
+// HTTP 401, service 24, case 01.
+type ResponseCode struct {
+	HTTPStatus  int
+	ServiceCode snap_validator_services.ServiceCode
+	CaseCode    string
+	// Code is the HTTP status followed by the case code, e.g. "40101".
+	Code    string
+	Message string
+	// Known is false when Code is not in the catalog; Message and Category
+	// are then derived from the HTTP status.
+	Known    bool
+	Category Category
+	Outcome  Outcome
+}
+
+func (r ResponseCode) String() string {
+	return strconv.Itoa(r.HTTPStatus) + string(r.ServiceCode) + r.CaseCode
+}
+
+// ParseResponseCode splits a responseCode received from a SNAP provider and
+// classifies it.
+func ParseResponseCode(responseCode string) (ResponseCode, error) {
+	if len(responseCode) != 7 {
+		return ResponseCode{}, fmt.Errorf("snap validator: response code %q must be 7 digits", responseCode)
+	}
+	for _, c := range responseCode {
+		if c < '0' || c > '9' {
+			return ResponseCode{}, fmt.Errorf("snap validator: response code %q must be 7 digits", responseCode)
+		}
+	}
+	status, _ := strconv.Atoi(responseCode[:3])
+	if http.StatusText(status) == "" {
+		return ResponseCode{}, fmt.Errorf("snap validator: response code %q has unknown HTTP status %d", responseCode, status)
+	}
+
+	parsed := ResponseCode{
+		HTTPStatus:  status,
+		ServiceCode: snap_validator_services.ServiceCode(responseCode[3:5]),
+		CaseCode:    responseCode[5:],
+		Code:        responseCode[:3] + responseCode[5:],
+	}
+	if responseCase, ok := LookupCase(parsed.Code); ok {
+		parsed.Known = true
+		parsed.Message = responseCase.Message
+		parsed.Category = responseCase.Category
+	} else {
+		parsed.Message = http.StatusText(status)
+		parsed.Category = categoryOf(status)
+	}
+	parsed.Outcome = outcomeOf(parsed.Code, status)
+	return parsed, nil
+}
+
+func categoryOf(status int) Category {
+	switch {
+	case status < 300:
+		return CategorySuccess
+	case status < 500:
+		return CategoryBusiness
+	}
+	return CategorySystem
+}
+
+func outcomeOf(code string, status int) Outcome {
+	switch {
+	case status == http.StatusAccepted, status == http.StatusGatewayTimeout:
+		return OutcomePending
+	case status < 300:
+		return OutcomeSuccess
+	case status == http.StatusTooManyRequests, status >= 500:
+		return OutcomeRetryable
+	case code == "40101", code == "40103":
+		return OutcomeRetryable
+	}
+	return OutcomeFatal
+}
@@ -0,0 +1,86 @@
+package snap_validator_errors
+
+import (
+	"errors"
+	"strings"
+)
+
+// Sentinel matches validation errors by code with errors.Is. A sentinel with
+// a three digit prefix, e.g. ErrBadRequest, matches every case of that HTTP
+// status; a five digit one matches a single case.
+//
+//	if errors.Is(err, snap_validator_errors.ErrMissingMandatoryField) { ... }
+type Sentinel struct {
+	prefix  string
+	message string
+}
+
+func (s *Sentinel) Error() string {
+	return s.message
+}
+
+// Matches reports whether code, either a five digit code or a SNAP code with
+// the service code spliced in, belongs to s.
+func (s *Sentinel) Matches(code string) bool {
+	if len(code) == 7 {
+		code = code[:3] + code[5:]
+	}
+	return strings.HasPrefix(code, s.prefix)
+}
+
+// Category sentinels, one per HTTP status.
+var (
+	ErrBadRequest      = &Sentinel{prefix: "400", message: "Bad Request"}
+	ErrUnauthorized    = &Sentinel{prefix: "401", message: "Unauthorized"}
+	ErrForbidden       = &Sentinel{prefix: "403", message: "Forbidden"}
+	ErrNotFound        = &Sentinel{prefix: "404", message: "Not Found"}
+	ErrConflict        = &Sentinel{prefix: "409", message: "Conflict"}
+	ErrTooManyRequests = &Sentinel{prefix: "429", message: "Too Many Requests"}
+	ErrServerError     = &Sentinel{prefix: "5", message: "Server Error"}
+)
+
+// Case sentinels.
+var (
+	ErrInvalidFieldFormat          = &Sentinel{prefix: "40001", message: "Invalid Field Format"}
+	ErrMissingMandatoryField       = &Sentinel{prefix: "40002", message: "Missing Mandatory Field"}
+	ErrInvalidToken                = &Sentinel{prefix: "40101", message: "Invalid Token (B2B)"}
+	ErrTokenNotFound               = &Sentinel{prefix: "40103", message: "Token Not Found (B2B)"}
+	ErrDuplicateExternalID         = &Sentinel{prefix: "40900", message: "Conflict"}
+	ErrDuplicatePartnerReferenceNo = &Sentinel{prefix: "40901", message: "Duplicate partnerReferenceNo"}
+	ErrGeneralError                = &Sentinel{prefix: "50000", message: "General Error"}
+)
+
+// Is matches sentinels by code and other *ErrorValidation values by Code.
+func (e *ErrorValidation) Is(target error) bool {
+	switch target := target.(type) {
+	case *Sentinel:
+		return target.Matches(e.Code)
+	case *ErrorValidation:
+		return target.Code != "" && target.Code == e.Code
+	}
+	return false
+}
+
+// Unwrap returns the cause of e, e.g. the error returned by a custom rule.
+func (e *ErrorValidation) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether any of the errors matches target.
+func (e ValidationErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap returns the errors so errors.As finds the primary *ErrorValidation.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
@@ -0,0 +1,219 @@
+// Package snap_validator_headers validates the standard SNAP request headers.
+//
+//	err := snap_validator_headers.New().ValidateRequest(r, snap_validator_services.VirtualAccountPayment)
+//
+// Failures are *snap_validator_errors.ErrorValidation values with the SNAP
+// code of the service, e.g. 4012500 for a missing Authorization header or
+// 4002502 for a missing X-PARTNER-ID.
+package snap_validator_headers
+
+import (
+	"context"
+	"github.com/apelweb15/snap-validator/snap_validator_errors"
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Standard SNAP header names.
+const (
+	HeaderAuthorization = "Authorization"
+	HeaderTimestamp     = "X-TIMESTAMP"
+	HeaderSignature     = "X-SIGNATURE"
+	HeaderPartnerID     = "X-PARTNER-ID"
+	HeaderExternalID    = "X-EXTERNAL-ID"
+	HeaderChannelID     = "CHANNEL-ID"
+	HeaderClientKey     = "X-CLIENT-KEY"
+)
+
+// TimestampLayout is the SNAP X-TIMESTAMP format. Fractional seconds are
+// accepted as well, e.g. 2024-06-01T10:00:00.000+07:00.
+const TimestampLayout = "2006-01-02T15:04:05-07:00"
+
+// DefaultClockSkew is how far X-TIMESTAMP may differ from the server clock.
+const DefaultClockSkew = 5 * time.Minute
+
+// MaxExternalIDLength is the maximum length of X-EXTERNAL-ID.
+const MaxExternalIDLength = 36
+
+// TransactionHeaders are required on transaction endpoints, e.g. transfer-va.
+var TransactionHeaders = []string{
+	HeaderAuthorization,
+	HeaderTimestamp,
+	HeaderSignature,
+	HeaderPartnerID,
+	HeaderExternalID,
+	HeaderChannelID,
+}
+
+// AccessTokenHeaders are required on the access token endpoints.
+var AccessTokenHeaders = []string{
+	HeaderTimestamp,
+	HeaderClientKey,
+	HeaderSignature,
+}
+
+var (
+	bearerRegex     = regexp.MustCompile(`^Bearer ([A-Za-z0-9\-._~+/]+=*)$`)
+	partnerIDRegex  = regexp.MustCompile(`^[A-Za-z0-9\-_]{1,36}$`)
+	externalIDRegex = regexp.MustCompile(`^[0-9]+$`)
+	channelIDRegex  = regexp.MustCompile(`^[0-9]{5}$`)
+	clientKeyRegex  = regexp.MustCompile(`^[A-Za-z0-9\-_.]{1,64}$`)
+)
+
+// Validator checks SNAP headers. Create it with New.
+type Validator struct {
+	required  []string
+	clockSkew time.Duration
+	now       func() time.Time
+}
+
+// Option configures a Validator created with New.
+type Option func(*Validator)
+
+// WithRequiredHeaders replaces the headers that must be present, e.g.
+// AccessTokenHeaders. Headers that are present but not required are still
+// checked for their format.
+func WithRequiredHeaders(headers ...string) Option {
+	return func(v *Validator) {
+		v.required = headers
+	}
+}
+
+// WithClockSkew sets how far X-TIMESTAMP may differ from the server clock.
+// A skew of zero disables the check.
+func WithClockSkew(skew time.Duration) Option {
+	return func(v *Validator) {
+		v.clockSkew = skew
+	}
+}
+
+// WithClock replaces time.Now, e.g. in tests.
+func WithClock(now func() time.Time) Option {
+	return func(v *Validator) {
+		v.now = now
+	}
+}
+
+// New returns a Validator for TransactionHeaders with DefaultClockSkew.
+func New(opts ...Option) *Validator {
+	v := &Validator{
+		required:  TransactionHeaders,
+		clockSkew: DefaultClockSkew,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Validate checks header for serviceCode and returns the first failure.
+// Authorization is checked first so that unauthenticated requests get 401.
+func (v *Validator) Validate(header http.Header, serviceCode snap_validator_services.ServiceCode) error {
+	return v.validate(context.Background(), header, serviceCode)
+}
+
+// ValidateRequest is Validate with the message locale taken from the request
+// context when set with snap_validator_errors.ContextWithLocale.
+func (v *Validator) ValidateRequest(r *http.Request, serviceCode snap_validator_services.ServiceCode) error {
+	return v.validate(r.Context(), r.Header, serviceCode)
+}
+
+func (v *Validator) validate(ctx context.Context, header http.Header, serviceCode snap_validator_services.ServiceCode) error {
+	if err := serviceCode.Validate(); err != nil {
+		return err
+	}
+	checks := []struct {
+		name  string
+		check func(value string) (code string, rule string, params []string)
+	}{
+		{HeaderAuthorization, v.checkAuthorization},
+		{HeaderTimestamp, v.checkTimestamp},
+		{HeaderSignature, nil},
+		{HeaderPartnerID, matches(partnerIDRegex, "partner_id")},
+		{HeaderExternalID, v.checkExternalID},
+		{HeaderChannelID, matches(channelIDRegex, "channel_id")},
+		{HeaderClientKey, matches(clientKeyRegex, "client_key")},
+	}
+	for _, c := range checks {
+		value := strings.TrimSpace(header.Get(c.name))
+		if value == "" {
+			if !v.isRequired(c.name) {
+				continue
+			}
+			code := "40002"
+			if c.name == HeaderAuthorization {
+				code = "40100"
+			}
+			return newError(ctx, code, c.name, "required", nil, serviceCode)
+		}
+		if c.check == nil {
+			continue
+		}
+		if code, rule, params := c.check(value); code != "" {
+			return newError(ctx, code, c.name, rule, params, serviceCode)
+		}
+	}
+	return nil
+}
+
+func (v *Validator) isRequired(name string) bool {
+	for _, required := range v.required {
+		if strings.EqualFold(required, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *Validator) checkAuthorization(value string) (string, string, []string) {
+	if !bearerRegex.MatchString(value) {
+		return "40101", "bearer", nil
+	}
+	return "", "", nil
+}
+
+func (v *Validator) checkTimestamp(value string) (string, string, []string) {
+	timestamp, err := time.Parse(TimestampLayout, value)
+	if err != nil {
+		return "40001", "iso_date", nil
+	}
+	if v.clockSkew > 0 {
+		diff := v.now().Sub(timestamp)
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > v.clockSkew {
+			return "40001", "clock_skew", []string{v.clockSkew.String()}
+		}
+	}
+	return "", "", nil
+}
+
+func (v *Validator) checkExternalID(value string) (string, string, []string) {
+	if !externalIDRegex.MatchString(value) || len(value) > MaxExternalIDLength {
+		return "40001", "external_id", []string{strconv.Itoa(MaxExternalIDLength)}
+	}
+	return "", "", nil
+}
+
+// matches checks value against regex; rule names the catalog detail of a
+// mismatch.
+func matches(regex *regexp.Regexp, rule string) func(string) (string, string, []string) {
+	return func(value string) (string, string, []string) {
+		if !regex.MatchString(value) {
+			return "40001", rule, nil
+		}
+		return "", "", nil
+	}
+}
+
+// newError builds the error of header name with the catalog detail of rule,
+// both in the locale of ctx.
+func newError(ctx context.Context, code string, name string, rule string, params []string, serviceCode snap_validator_services.ServiceCode) *snap_validator_errors.ErrorValidation {
+	return snap_validator_errors.NewErrorSnapLocale(ctx, code, name, string(serviceCode), rule, params, nil)
+}
@@ -0,0 +1,228 @@
+// Package snap_validator_idempotency detects duplicate SNAP requests.
+//
+// X-EXTERNAL-ID must be unique per partner per day; a duplicate is reported
+// as 409 Conflict (40900). partnerReferenceNo must be unique per partner; a
+// duplicate is reported as 409 Duplicate partnerReferenceNo (40901).
+//
+//	checker := snap_validator_idempotency.New()
+//	if err := checker.CheckRequest(r, req.PartnerReferenceNo, serviceCode); err != nil {
+//		snap_validator_errors.ResponseFromError(err, string(serviceCode)).Write(w)
+//		return
+//	}
+package snap_validator_idempotency
+
+import (
+	"context"
+	"github.com/apelweb15/snap-validator/snap_validator_errors"
+	"github.com/apelweb15/snap-validator/snap_validator_headers"
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WIB is Western Indonesian Time (UTC+07:00), the default day boundary of
+// X-EXTERNAL-ID uniqueness.
+var WIB = time.FixedZone("WIB", 7*60*60)
+
+// DefaultReferenceTTL is how long a partnerReferenceNo is remembered unless
+// WithReferenceTTL is used.
+const DefaultReferenceTTL = 24 * time.Hour
+
+// Store records keys. SetIfAbsent must be atomic: of several concurrent calls
+// with the same key, only one may return true until ttl has passed. Delete
+// releases a key, e.g. when a later check of the same request fails.
+type Store interface {
+	SetIfAbsent(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryStore is a Store for a single process. An expired key is replaced
+// when it is set again; the others are swept once as many keys have been set
+// as the store holds, so SetIfAbsent stays amortized O(1).
+type MemoryStore struct {
+	mu   sync.Mutex
+	keys map[string]time.Time
+	now  func() time.Time
+	// sets counts the keys set since the last sweep.
+	sets int
+}
+
+// minSweepSets keeps small stores from being swept on every set.
+const minSweepSets = 64
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{keys: map[string]time.Time{}, now: time.Now}
+}
+
+func (s *MemoryStore) SetIfAbsent(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if expiresAt, ok := s.keys[key]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+	s.keys[key] = now.Add(ttl)
+	s.sets++
+	if s.sets >= minSweepSets && s.sets >= len(s.keys) {
+		s.sweep(now)
+	}
+	return true, nil
+}
+
+// sweep removes the keys expired at now.
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, expiresAt := range s.keys {
+		if !now.Before(expiresAt) {
+			delete(s.keys, key)
+		}
+	}
+	s.sets = 0
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, key)
+	return nil
+}
+
+// Checker records X-EXTERNAL-ID and partnerReferenceNo values and reports
+// duplicates. Create it with New.
+type Checker struct {
+	store        Store
+	location     *time.Location
+	referenceTTL time.Duration
+	now          func() time.Time
+}
+
+// Option configures a Checker created with New.
+type Option func(*Checker)
+
+// WithStore records keys in store instead of a MemoryStore, e.g. one backed
+// by Redis SET NX to share them between instances.
+func WithStore(store Store) Option {
+	return func(c *Checker) {
+		c.store = store
+	}
+}
+
+// WithLocation sets the time zone whose midnight resets X-EXTERNAL-ID
+// uniqueness.
+func WithLocation(location *time.Location) Option {
+	return func(c *Checker) {
+		c.location = location
+	}
+}
+
+// WithReferenceTTL sets how long a partnerReferenceNo is remembered.
+func WithReferenceTTL(ttl time.Duration) Option {
+	return func(c *Checker) {
+		c.referenceTTL = ttl
+	}
+}
+
+// WithClock replaces time.Now, e.g. in tests.
+func WithClock(now func() time.Time) Option {
+	return func(c *Checker) {
+		c.now = now
+	}
+}
+
+// New returns a Checker backed by a MemoryStore using WIB days.
+func New(opts ...Option) *Checker {
+	c := &Checker{location: WIB, referenceTTL: DefaultReferenceTTL, now: time.Now}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.store == nil {
+		store := NewMemoryStore()
+		store.now = c.now
+		c.store = store
+	}
+	return c
+}
+
+// CheckExternalID records externalID for partnerID and returns 40900
+// Conflict if it was already used today, or 40002 Missing Mandatory Field if
+// it is empty. The message locale is taken from ctx when set with
+// snap_validator_errors.ContextWithLocale.
+func (c *Checker) CheckExternalID(ctx context.Context, partnerID string, externalID string, serviceCode snap_validator_services.ServiceCode) error {
+	if err := serviceCode.Validate(); err != nil {
+		return err
+	}
+	_, err := c.checkExternalID(ctx, partnerID, externalID, serviceCode)
+	return err
+}
+
+// checkExternalID is CheckExternalID returning the recorded key, so that it
+// can be released even when the day has changed since.
+func (c *Checker) checkExternalID(ctx context.Context, partnerID string, externalID string, serviceCode snap_validator_services.ServiceCode) (string, error) {
+	if externalID == "" {
+		return "", snap_validator_errors.NewErrorSnapLocale(ctx, "40002", snap_validator_headers.HeaderExternalID, string(serviceCode), "required", nil, nil)
+	}
+	key, ttl := c.externalIDKey(partnerID, externalID)
+	return key, c.record(ctx, key, ttl, "40900", snap_validator_headers.HeaderExternalID, serviceCode)
+}
+
+// CheckPartnerReferenceNo records partnerReferenceNo for partnerID and
+// returns 40901 Duplicate partnerReferenceNo if it was already used.
+func (c *Checker) CheckPartnerReferenceNo(ctx context.Context, partnerID string, partnerReferenceNo string, serviceCode snap_validator_services.ServiceCode) error {
+	if err := serviceCode.Validate(); err != nil {
+		return err
+	}
+	return c.record(ctx, c.referenceKey(partnerID, partnerReferenceNo), c.referenceTTL, "40901", "partnerReferenceNo", serviceCode)
+}
+
+// CheckRequest checks the X-EXTERNAL-ID of r and, when not empty,
+// partnerReferenceNo, both for the X-PARTNER-ID of r. If partnerReferenceNo
+// is a duplicate, the external ID is released again so that a corrected
+// retry with the same X-EXTERNAL-ID is accepted.
+func (c *Checker) CheckRequest(r *http.Request, partnerReferenceNo string, serviceCode snap_validator_services.ServiceCode) error {
+	ctx := r.Context()
+	partnerID := r.Header.Get(snap_validator_headers.HeaderPartnerID)
+	externalID := r.Header.Get(snap_validator_headers.HeaderExternalID)
+	if err := serviceCode.Validate(); err != nil {
+		return err
+	}
+	key, err := c.checkExternalID(ctx, partnerID, externalID, serviceCode)
+	if err != nil {
+		return err
+	}
+	if partnerReferenceNo == "" {
+		return nil
+	}
+	if err := c.CheckPartnerReferenceNo(ctx, partnerID, partnerReferenceNo, serviceCode); err != nil {
+		if deleteErr := c.store.Delete(ctx, key); deleteErr != nil {
+			return snap_validator_errors.NewErrorSnapLocale(ctx, "50000", "", string(serviceCode), "", nil, deleteErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// externalIDKey returns the key of externalID and its time to live, which
+// ends at the next midnight of the checker's location.
+func (c *Checker) externalIDKey(partnerID string, externalID string) (string, time.Duration) {
+	now := c.now().In(c.location)
+	year, month, day := now.Date()
+	midnight := time.Date(year, month, day+1, 0, 0, 0, 0, c.location)
+	return "external-id:" + partnerID + ":" + now.Format("20060102") + ":" + externalID, midnight.Sub(now)
+}
+
+func (c *Checker) referenceKey(partnerID string, partnerReferenceNo string) string {
+	return "partner-reference-no:" + partnerID + ":" + partnerReferenceNo
+}
+
+func (c *Checker) record(ctx context.Context, key string, ttl time.Duration, code string, label string, serviceCode snap_validator_services.ServiceCode) error {
+	ok, err := c.store.SetIfAbsent(ctx, key, ttl)
+	if err != nil {
+		return snap_validator_errors.NewErrorSnapLocale(ctx, "50000", "", string(serviceCode), "", nil, err)
+	}
+	if ok {
+		return nil
+	}
+	// 409 messages name the duplicate field on their own.
+	return snap_validator_errors.NewErrorSnapLocale(ctx, code, "", string(serviceCode), "", nil, nil)
+}
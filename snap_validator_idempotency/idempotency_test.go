@@ -0,0 +1,131 @@
+package snap_validator_idempotency
+
+import (
+	"context"
+	"errors"
+	"github.com/apelweb15/snap-validator/snap_validator_errors"
+	"github.com/apelweb15/snap-validator/snap_validator_headers"
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCheckExternalID(t *testing.T) {
+	now := time.Date(2024, 6, 1, 16, 30, 0, 0, time.UTC) // 23:30 WIB
+	checker := New(WithClock(func() time.Time { return now }))
+	ctx := context.Background()
+	serviceCode := snap_validator_services.VirtualAccountPayment
+
+	assert.NoError(t, checker.CheckExternalID(ctx, "partner", "41807553358950093184162180797837", serviceCode))
+	assert.NoError(t, checker.CheckExternalID(ctx, "other", "41807553358950093184162180797837", serviceCode))
+
+	err := checker.CheckExternalID(ctx, "partner", "41807553358950093184162180797837", serviceCode)
+	var errorRes *snap_validator_errors.ErrorValidation
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "4092500", errorRes.SnapCode)
+	assert.Equal(t, "Conflict", errorRes.Message)
+	assert.Equal(t, http.StatusConflict, errorRes.HTTPStatus())
+	assert.True(t, errors.Is(err, snap_validator_errors.ErrDuplicateExternalID))
+
+	now = now.Add(time.Hour) // 00:30 WIB the next day
+	assert.NoError(t, checker.CheckExternalID(ctx, "partner", "41807553358950093184162180797837", serviceCode))
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < minSweepSets-2; i++ {
+		ok, err := store.SetIfAbsent(ctx, strconv.Itoa(i), time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	}
+	now = now.Add(time.Minute)
+	ok, err := store.SetIfAbsent(ctx, "0", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, ok, "an expired key can be set again")
+	assert.Len(t, store.keys, minSweepSets-2)
+
+	ok, _ = store.SetIfAbsent(ctx, "new", time.Minute)
+	assert.True(t, ok)
+	assert.Len(t, store.keys, 2, "expired keys are swept")
+}
+
+func TestCheckRequest(t *testing.T) {
+	checker := New()
+	serviceCode := snap_validator_services.IntrabankTransfer
+	request := func(externalID string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/v1.0/transfer-intrabank", nil)
+		r.Header.Set(snap_validator_headers.HeaderPartnerID, "partner")
+		r.Header.Set(snap_validator_headers.HeaderExternalID, externalID)
+		return r
+	}
+
+	assert.NoError(t, checker.CheckRequest(request("1"), "2020102900000000000001", serviceCode))
+	err := checker.CheckRequest(request("2"), "2020102900000000000001", serviceCode)
+	var errorRes *snap_validator_errors.ErrorValidation
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "4091701", errorRes.SnapCode)
+	assert.Equal(t, "Duplicate partnerReferenceNo", errorRes.Message)
+	assert.True(t, errors.Is(err, snap_validator_errors.ErrDuplicatePartnerReferenceNo))
+
+	// The external ID of the rejected request is released for a corrected retry.
+	assert.NoError(t, checker.CheckRequest(request("2"), "2020102900000000000002", serviceCode))
+	assert.True(t, errors.Is(checker.CheckRequest(request("2"), "2020102900000000000003", serviceCode), snap_validator_errors.ErrDuplicateExternalID))
+
+	for i := 0; i < 2; i++ {
+		err = checker.CheckRequest(request(""), "", serviceCode)
+		assert.True(t, errors.As(err, &errorRes))
+		assert.Equal(t, "4001702", errorRes.SnapCode)
+		assert.Equal(t, "Missing Mandatory Field X-EXTERNAL-ID", errorRes.Message)
+	}
+}
+
+func TestCheckRequestAtMidnight(t *testing.T) {
+	// The clock crosses midnight WIB after the external ID is recorded.
+	calls := 0
+	clock := func() time.Time {
+		calls++
+		if calls > 2 {
+			return time.Date(2024, 6, 1, 17, 0, 1, 0, time.UTC)
+		}
+		return time.Date(2024, 6, 1, 16, 59, 59, 0, time.UTC)
+	}
+	store := NewMemoryStore()
+	store.now = clock
+	checker := New(WithStore(store), WithClock(clock))
+	ctx := context.Background()
+	serviceCode := snap_validator_services.IntrabankTransfer
+	_, _ = store.SetIfAbsent(ctx, checker.referenceKey("partner", "1"), time.Hour)
+
+	r := httptest.NewRequest(http.MethodPost, "/v1.0/transfer-intrabank", nil)
+	r.Header.Set(snap_validator_headers.HeaderPartnerID, "partner")
+	r.Header.Set(snap_validator_headers.HeaderExternalID, "2")
+	assert.True(t, errors.Is(checker.CheckRequest(r, "1", serviceCode), snap_validator_errors.ErrDuplicatePartnerReferenceNo))
+	assert.NotContains(t, store.keys, "external-id:partner:20240601:2", "the recorded key is released")
+}
+
+func TestConcurrentDuplicates(t *testing.T) {
+	checker := New()
+	var accepted int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if checker.CheckExternalID(context.Background(), "partner", "1", snap_validator_services.VirtualAccountPayment) == nil {
+				atomic.AddInt32(&accepted, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), accepted)
+}
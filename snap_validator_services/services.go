@@ -0,0 +1,138 @@
+package snap_validator_services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ServiceCode is the two digit SNAP service code spliced into response codes,
+// e.g. 25 in 4002501.
+type ServiceCode string
+
+const (
+	BalanceInquiry            ServiceCode = "11"
+	TransactionHistoryList    ServiceCode = "12"
+	TransactionHistoryDetail  ServiceCode = "13"
+	BankStatement             ServiceCode = "14"
+	InternalAccountInquiry    ServiceCode = "15"
+	ExternalAccountInquiry    ServiceCode = "16"
+	IntrabankTransfer         ServiceCode = "17"
+	InterbankTransfer         ServiceCode = "18"
+	RtgsTransfer              ServiceCode = "22"
+	SknTransfer               ServiceCode = "23"
+	VirtualAccountInquiry     ServiceCode = "24"
+	VirtualAccountPayment     ServiceCode = "25"
+	VirtualAccountStatus      ServiceCode = "26"
+	VirtualAccountCreate      ServiceCode = "27"
+	VirtualAccountUpdate      ServiceCode = "28"
+	VirtualAccountUpdateState ServiceCode = "29"
+	VirtualAccountInquiryVA   ServiceCode = "30"
+	VirtualAccountDelete      ServiceCode = "31"
+	VirtualAccountReport      ServiceCode = "32"
+	TransferStatusInquiry     ServiceCode = "36"
+	QrisMpmGenerate           ServiceCode = "47"
+	QrisMpmQuery              ServiceCode = "51"
+	QrisMpmNotify             ServiceCode = "52"
+	AccessTokenB2B            ServiceCode = "73"
+	AccessTokenB2B2C          ServiceCode = "74"
+)
+
+var ErrInvalidServiceCode = errors.New("snap validator: invalid service code")
+
+// Service is an entry of the service code registry.
+type Service struct {
+	Code ServiceCode
+	Name string
+}
+
+var (
+	mu       sync.RWMutex
+	registry = map[ServiceCode]string{
+		BalanceInquiry:            "Balance Inquiry",
+		TransactionHistoryList:    "Transaction History List",
+		TransactionHistoryDetail:  "Transaction History Detail",
+		BankStatement:             "Bank Statement",
+		InternalAccountInquiry:    "Internal Account Inquiry",
+		ExternalAccountInquiry:    "External Account Inquiry",
+		IntrabankTransfer:         "Intrabank Transfer",
+		InterbankTransfer:         "Interbank Transfer",
+		RtgsTransfer:              "RTGS Transfer",
+		SknTransfer:               "SKN Transfer",
+		VirtualAccountInquiry:     "Virtual Account Inquiry",
+		VirtualAccountPayment:     "Virtual Account Payment",
+		VirtualAccountStatus:      "Virtual Account Payment Status",
+		VirtualAccountCreate:      "Create Virtual Account",
+		VirtualAccountUpdate:      "Update Virtual Account",
+		VirtualAccountUpdateState: "Update Virtual Account Status",
+		VirtualAccountInquiryVA:   "Inquiry Virtual Account",
+		VirtualAccountDelete:      "Delete Virtual Account",
+		VirtualAccountReport:      "Virtual Account Report",
+		TransferStatusInquiry:     "Transfer Status Inquiry",
+		QrisMpmGenerate:           "QRIS MPM Generate",
+		QrisMpmQuery:              "QRIS MPM Query",
+		QrisMpmNotify:             "QRIS MPM Notify",
+		AccessTokenB2B:            "Access Token B2B",
+		AccessTokenB2B2C:          "Access Token B2B2C",
+	}
+)
+
+func (c ServiceCode) String() string {
+	return string(c)
+}
+
+// Valid reports whether c is well formed: exactly two digits.
+func (c ServiceCode) Valid() bool {
+	return len(c) == 2 && c[0] >= '0' && c[0] <= '9' && c[1] >= '0' && c[1] <= '9'
+}
+
+// Validate returns ErrInvalidServiceCode when c is not well formed. Unknown
+// but well formed codes are accepted so new SNAP services work before they
+// are added to the registry.
+func (c ServiceCode) Validate() error {
+	if !c.Valid() {
+		return fmt.Errorf("%w: %q must be two digits", ErrInvalidServiceCode, string(c))
+	}
+	return nil
+}
+
+// Name returns the registered service name, or "" for unknown codes.
+func (c ServiceCode) Name() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return registry[c]
+}
+
+// Lookup returns the registered service for code.
+func Lookup(code string) (Service, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	name, ok := registry[ServiceCode(code)]
+	return Service{Code: ServiceCode(code), Name: name}, ok
+}
+
+// Register adds or renames a service, e.g. a bank specific extension.
+func Register(code ServiceCode, name string) error {
+	if err := code.Validate(); err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	registry[code] = name
+	return nil
+}
+
+// Services returns the registered services ordered by code.
+func Services() []Service {
+	mu.RLock()
+	defer mu.RUnlock()
+	services := make([]Service, 0, len(registry))
+	for code, name := range registry {
+		services = append(services, Service{Code: code, Name: name})
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Code < services[j].Code
+	})
+	return services
+}
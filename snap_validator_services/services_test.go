@@ -0,0 +1,33 @@
+package snap_validator_services
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestServiceCode(t *testing.T) {
+	assert.NoError(t, VirtualAccountPayment.Validate())
+	assert.Equal(t, "Virtual Account Payment", VirtualAccountPayment.Name())
+	assert.True(t, errors.Is(ServiceCode("5").Validate(), ErrInvalidServiceCode))
+	assert.True(t, errors.Is(ServiceCode("251").Validate(), ErrInvalidServiceCode))
+	assert.True(t, errors.Is(ServiceCode("2a").Validate(), ErrInvalidServiceCode))
+
+	service, ok := Lookup("47")
+	assert.True(t, ok)
+	assert.Equal(t, QrisMpmGenerate, service.Code)
+
+	_, ok = Lookup("99")
+	assert.False(t, ok)
+	assert.NoError(t, Register("99", "Bank Specific Service"))
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		delete(registry, "99")
+	})
+	assert.Equal(t, "Bank Specific Service", ServiceCode("99").Name())
+	assert.Error(t, Register("999", "Invalid"))
+
+	services := Services()
+	assert.Equal(t, BalanceInquiry, services[0].Code)
+}
@@ -0,0 +1,154 @@
+package snap_validator_signature
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"github.com/apelweb15/snap-validator/snap_validator_headers"
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"net/http"
+)
+
+// ErrInvalidKey is returned when a key cannot be parsed or is not RSA.
+var ErrInvalidKey = errors.New("snap_validator_signature: invalid RSA key")
+
+// PublicKeyStore looks up the registered public key of a partner by
+// X-CLIENT-KEY. It returns ErrUnknownPartner when the client is not
+// registered and ErrInvalidKey when its registered key cannot be parsed.
+type PublicKeyStore interface {
+	PublicKey(ctx context.Context, clientKey string) (*rsa.PublicKey, error)
+}
+
+// PublicKeyStoreFunc adapts a function to PublicKeyStore.
+type PublicKeyStoreFunc func(ctx context.Context, clientKey string) (*rsa.PublicKey, error)
+
+func (f PublicKeyStoreFunc) PublicKey(ctx context.Context, clientKey string) (*rsa.PublicKey, error) {
+	return f(ctx, clientKey)
+}
+
+// StaticPublicKeys is a PublicKeyStore backed by a map of client key to
+// public key.
+type StaticPublicKeys map[string]*rsa.PublicKey
+
+func (s StaticPublicKeys) PublicKey(ctx context.Context, clientKey string) (*rsa.PublicKey, error) {
+	if key, ok := s[clientKey]; ok {
+		return key, nil
+	}
+	return nil, ErrUnknownPartner
+}
+
+// ParsePublicKey parses a PEM or DER encoded RSA public key: PKIX
+// ("PUBLIC KEY"), PKCS #1 ("RSA PUBLIC KEY") or an X.509 certificate.
+func ParsePublicKey(data []byte) (*rsa.PublicKey, error) {
+	der := data
+	if block, _ := pem.Decode(data); block != nil {
+		der = block.Bytes
+	}
+	if key, err := x509.ParsePKIXPublicKey(der); err == nil {
+		if rsaKey, ok := key.(*rsa.PublicKey); ok {
+			return rsaKey, nil
+		}
+		return nil, ErrInvalidKey
+	}
+	if key, err := x509.ParsePKCS1PublicKey(der); err == nil {
+		return key, nil
+	}
+	if certificate, err := x509.ParseCertificate(der); err == nil {
+		if rsaKey, ok := certificate.PublicKey.(*rsa.PublicKey); ok {
+			return rsaKey, nil
+		}
+	}
+	return nil, ErrInvalidKey
+}
+
+// ParsePrivateKey parses a PEM or DER encoded RSA private key in PKCS #8
+// ("PRIVATE KEY") or PKCS #1 ("RSA PRIVATE KEY") form.
+func ParsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	der := data
+	if block, _ := pem.Decode(data); block != nil {
+		der = block.Bytes
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		if rsaKey, ok := key.(*rsa.PrivateKey); ok {
+			return rsaKey, nil
+		}
+		return nil, ErrInvalidKey
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	return nil, ErrInvalidKey
+}
+
+// AsymmetricStringToSign builds clientKey|timestamp.
+func AsymmetricStringToSign(clientKey string, timestamp string) string {
+	return clientKey + "|" + timestamp
+}
+
+// SignAsymmetric returns base64(SHA256withRSA(privateKey, stringToSign)),
+// e.g. for the X-SIGNATURE of an outbound access token request.
+func SignAsymmetric(privateKey *rsa.PrivateKey, stringToSign string) (string, error) {
+	digest := sha256.Sum256([]byte(stringToSign))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// VerifyAsymmetric checks a base64 SHA256withRSA signature of stringToSign.
+func VerifyAsymmetric(publicKey *rsa.PublicKey, stringToSign string, signature string) error {
+	raw, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256([]byte(stringToSign))
+	return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], raw)
+}
+
+// AsymmetricVerifier verifies the X-SIGNATURE of access token requests.
+type AsymmetricVerifier struct {
+	keys PublicKeyStore
+}
+
+// NewAsymmetricVerifier returns a verifier that looks up keys in keys.
+func NewAsymmetricVerifier(keys PublicKeyStore) *AsymmetricVerifier {
+	return &AsymmetricVerifier{keys: keys}
+}
+
+// Verify checks the X-SIGNATURE of r against X-CLIENT-KEY|X-TIMESTAMP using
+// the public key registered for X-CLIENT-KEY. Failures are reported as
+// described in the package documentation.
+func (v *AsymmetricVerifier) Verify(r *http.Request, serviceCode snap_validator_services.ServiceCode) error {
+	if err := serviceCode.Validate(); err != nil {
+		return err
+	}
+	ctx := r.Context()
+	signature := r.Header.Get(snap_validator_headers.HeaderSignature)
+	if signature == "" {
+		return newError(ctx, "40002", snap_validator_headers.HeaderSignature, "required", serviceCode, nil)
+	}
+	clientKey := r.Header.Get(snap_validator_headers.HeaderClientKey)
+	if clientKey == "" {
+		return newError(ctx, "40002", snap_validator_headers.HeaderClientKey, "required", serviceCode, nil)
+	}
+	timestamp, err := timestampOf(ctx, r, serviceCode)
+	if err != nil {
+		return err
+	}
+	publicKey, err := v.keys.PublicKey(ctx, clientKey)
+	if err != nil {
+		return lookupError(ctx, err, snap_validator_headers.HeaderClientKey, serviceCode)
+	}
+	stringToSign := AsymmetricStringToSign(clientKey, timestamp)
+	if err := VerifyAsymmetric(publicKey, stringToSign, signature); err != nil {
+		return newError(ctx, "40101", snap_validator_headers.HeaderSignature, "signature", serviceCode, err)
+	}
+	return nil
+}
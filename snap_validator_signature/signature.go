@@ -0,0 +1,83 @@
+// Package snap_validator_signature creates and verifies SNAP X-SIGNATURE
+// headers: HMAC-SHA512 for transaction requests and SHA256withRSA for
+// access token requests.
+//
+// Verification failures are *snap_validator_errors.ErrorValidation values
+// with the SNAP code of the service. Both verifiers report:
+//
+//   - a missing X-SIGNATURE, X-TIMESTAMP, X-PARTNER-ID or X-CLIENT-KEY as
+//     40002 Missing Mandatory Field, e.g. 4002502 Missing Mandatory Field
+//     X-SIGNATURE
+//   - an X-TIMESTAMP that is not in snap_validator_headers.TimestampLayout as
+//     40001 Invalid Field Format
+//   - a partner or client without a registered key as 40100 Unauthorized
+//   - a signature that does not match, or a registered key that cannot be
+//     used, as 40101 Invalid Token (B2B)
+//   - a failing key store as 50000 General Error
+//
+// The verifiers do not check how old X-TIMESTAMP is; run
+// snap_validator_headers first to reject replayed requests.
+//
+// Messages follow the locale set with snap_validator_errors.ContextWithLocale
+// on the request context.
+package snap_validator_signature
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/apelweb15/snap-validator/snap_validator_errors"
+	"github.com/apelweb15/snap-validator/snap_validator_headers"
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ErrUnknownPartner is returned by stores that have no key for a partner.
+var ErrUnknownPartner = errors.New("snap_validator_signature: unknown partner")
+
+// readBody returns the request body and leaves it readable for the next
+// handler.
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// newError builds a service coded error in the locale of ctx. The detail is
+// the catalog entry "detail.<rule>".
+func newError(ctx context.Context, code string, label string, rule string, serviceCode snap_validator_services.ServiceCode, cause error) *snap_validator_errors.ErrorValidation {
+	return snap_validator_errors.NewErrorSnapLocale(ctx, code, label, string(serviceCode), rule, nil, cause)
+}
+
+// timestampOf returns the X-TIMESTAMP of r, which is part of every string to
+// sign, so that a signature cannot be verified without it.
+func timestampOf(ctx context.Context, r *http.Request, serviceCode snap_validator_services.ServiceCode) (string, error) {
+	value := r.Header.Get(snap_validator_headers.HeaderTimestamp)
+	if value == "" {
+		return "", newError(ctx, "40002", snap_validator_headers.HeaderTimestamp, "required", serviceCode, nil)
+	}
+	if _, err := time.Parse(snap_validator_headers.TimestampLayout, value); err != nil {
+		return "", newError(ctx, "40001", snap_validator_headers.HeaderTimestamp, "iso_date", serviceCode, err)
+	}
+	return value, nil
+}
+
+// lookupError reports ErrUnknownPartner as 40100, ErrInvalidKey as 40101 and
+// any other store failure as 50000.
+func lookupError(ctx context.Context, err error, label string, serviceCode snap_validator_services.ServiceCode) error {
+	if errors.Is(err, ErrUnknownPartner) {
+		return newError(ctx, "40100", label, "unknown_partner", serviceCode, err)
+	}
+	if errors.Is(err, ErrInvalidKey) {
+		return newError(ctx, "40101", label, "invalid_key", serviceCode, err)
+	}
+	return newError(ctx, "50000", "", "key_lookup", serviceCode, err)
+}
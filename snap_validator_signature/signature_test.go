@@ -0,0 +1,170 @@
+package snap_validator_signature
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"github.com/apelweb15/snap-validator/snap_validator_errors"
+	"github.com/apelweb15/snap-validator/snap_validator_headers"
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const (
+	partnerID    = "82150823919040624621823174737537"
+	clientSecret = "mySecret"
+	accessToken  = "gp9HjjEj813Y9JGoqwOeOPWbnt4CUpvIJbU1mMU4a11MNDZ7Sg5u9a"
+	timestamp    = "2024-06-01T10:00:00+07:00"
+)
+
+func TestSymmetricStringToSign(t *testing.T) {
+	stringToSign, err := SymmetricStringToSign("post", "/v1.0/transfer-va/payment", "Bearer "+accessToken, []byte("{\n  \"a\": 1,\n  \"b\": \"x y\"\n}"), timestamp)
+	assert.NoError(t, err)
+	minifiedHash, _ := BodyHash([]byte(`{"a":1,"b":"x y"}`))
+	assert.Equal(t, "POST:/v1.0/transfer-va/payment:"+accessToken+":"+minifiedHash+":"+timestamp, stringToSign)
+
+	emptyHash, err := BodyHash(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", emptyHash)
+
+	_, err = BodyHash([]byte(`{"a":`))
+	assert.Error(t, err)
+}
+
+func signedRequest(t *testing.T, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/v1.0/transfer-va/payment?lang=id", strings.NewReader(body))
+	r.Header.Set(snap_validator_headers.HeaderAuthorization, "Bearer "+accessToken)
+	r.Header.Set(snap_validator_headers.HeaderTimestamp, timestamp)
+	r.Header.Set(snap_validator_headers.HeaderPartnerID, partnerID)
+	stringToSign, err := SymmetricStringToSign(r.Method, r.URL.RequestURI(), accessToken, []byte(body), timestamp)
+	assert.NoError(t, err)
+	r.Header.Set(snap_validator_headers.HeaderSignature, SignSymmetric(clientSecret, stringToSign))
+	return r
+}
+
+func TestSymmetricVerifier(t *testing.T) {
+	verifier := NewSymmetricVerifier(StaticSecrets{partnerID: clientSecret})
+	serviceCode := snap_validator_services.VirtualAccountPayment
+	body := `{"partnerServiceId": "   12345", "customerNo": "123"}`
+
+	r := signedRequest(t, body)
+	assert.NoError(t, verifier.Verify(r, serviceCode))
+	raw, _ := io.ReadAll(r.Body)
+	assert.Equal(t, body, string(raw))
+
+	var errorRes *snap_validator_errors.ErrorValidation
+	r = signedRequest(t, body)
+	r.Body = io.NopCloser(strings.NewReader(`{"partnerServiceId": "   12345", "customerNo": "124"}`))
+	err := verifier.Verify(r, serviceCode)
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "4012501", errorRes.SnapCode)
+	assert.Equal(t, "Invalid Token (B2B) X-SIGNATURE", errorRes.Message)
+
+	r = signedRequest(t, body)
+	r.Header.Set(snap_validator_headers.HeaderPartnerID, "unknown")
+	err = verifier.Verify(r, serviceCode)
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "Unauthorized X-PARTNER-ID", errorRes.Message)
+	assert.True(t, errors.Is(err, ErrUnknownPartner))
+
+	r = signedRequest(t, body)
+	r.Header.Del(snap_validator_headers.HeaderSignature)
+	err = verifier.Verify(r, serviceCode)
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "4002502", errorRes.SnapCode)
+	assert.Equal(t, "Missing Mandatory Field X-SIGNATURE", errorRes.Message)
+
+	errStore := errors.New("store down")
+	verifier = NewSymmetricVerifier(SecretStoreFunc(func(ctx context.Context, partnerID string) (string, error) {
+		return "", errStore
+	}))
+	err = verifier.Verify(signedRequest(t, body), serviceCode)
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "5002500", errorRes.SnapCode)
+	assert.True(t, errors.Is(err, errStore))
+}
+
+func TestAsymmetricVerifier(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	publicDer, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	assert.NoError(t, err)
+
+	publicKey, err := ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDer}))
+	assert.NoError(t, err)
+	_, err = ParsePublicKey(x509.MarshalPKCS1PublicKey(&privateKey.PublicKey))
+	assert.NoError(t, err)
+	parsedPrivate, err := ParsePrivateKey(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)}))
+	assert.NoError(t, err)
+	_, err = ParsePublicKey([]byte("not a key"))
+	assert.True(t, errors.Is(err, ErrInvalidKey))
+
+	clientKey := "ac83fe7a-aa4a-4b7b-9d4d-1c5f0a4e3a2b"
+	signature, err := SignAsymmetric(parsedPrivate, AsymmetricStringToSign(clientKey, timestamp))
+	assert.NoError(t, err)
+
+	verifier := NewAsymmetricVerifier(StaticPublicKeys{clientKey: publicKey})
+	serviceCode := snap_validator_services.AccessTokenB2B
+	request := func() *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/v1.0/access-token/b2b", strings.NewReader(`{"grantType":"client_credentials"}`))
+		r.Header.Set(snap_validator_headers.HeaderClientKey, clientKey)
+		r.Header.Set(snap_validator_headers.HeaderTimestamp, timestamp)
+		r.Header.Set(snap_validator_headers.HeaderSignature, signature)
+		return r
+	}
+	assert.NoError(t, verifier.Verify(request(), serviceCode))
+
+	var errorRes *snap_validator_errors.ErrorValidation
+	r := request()
+	r.Header.Set(snap_validator_headers.HeaderTimestamp, "2024-06-01T10:00:01+07:00")
+	assert.True(t, errors.As(verifier.Verify(r, serviceCode), &errorRes))
+	assert.Equal(t, "4017301", errorRes.SnapCode)
+	assert.Equal(t, "Invalid Token (B2B) X-SIGNATURE", errorRes.Message)
+	assert.Equal(t, "signature does not match", errorRes.Detail)
+
+	r = request()
+	r.Header.Set(snap_validator_headers.HeaderSignature, "not base64")
+	r = r.WithContext(snap_validator_errors.ContextWithLocale(r.Context(), snap_validator_errors.LocaleIndonesian))
+	assert.True(t, errors.As(verifier.Verify(r, serviceCode), &errorRes))
+	assert.Equal(t, "4017301", errorRes.SnapCode)
+	assert.Equal(t, "tanda tangan tidak sesuai", errorRes.Detail)
+
+	r = request()
+	r.Header.Del(snap_validator_headers.HeaderClientKey)
+	assert.True(t, errors.As(verifier.Verify(r, serviceCode), &errorRes))
+	assert.Equal(t, "4007302", errorRes.SnapCode)
+	assert.Equal(t, "Missing Mandatory Field X-CLIENT-KEY", errorRes.Message)
+
+	r = request()
+	r.Header.Del(snap_validator_headers.HeaderTimestamp)
+	assert.True(t, errors.As(verifier.Verify(r, serviceCode), &errorRes))
+	assert.Equal(t, "4007302", errorRes.SnapCode)
+	assert.Equal(t, "Missing Mandatory Field X-TIMESTAMP", errorRes.Message)
+
+	r = request()
+	r.Header.Set(snap_validator_headers.HeaderTimestamp, "2024-06-01 10:00:00")
+	assert.True(t, errors.As(verifier.Verify(r, serviceCode), &errorRes))
+	assert.Equal(t, "4007301", errorRes.SnapCode)
+	assert.Equal(t, "Invalid Field Format X-TIMESTAMP", errorRes.Message)
+
+	invalidKeys := NewAsymmetricVerifier(PublicKeyStoreFunc(func(ctx context.Context, clientKey string) (*rsa.PublicKey, error) {
+		return ParsePublicKey([]byte("not a key"))
+	}))
+	assert.True(t, errors.As(invalidKeys.Verify(request(), serviceCode), &errorRes))
+	assert.Equal(t, "4017301", errorRes.SnapCode)
+	assert.True(t, errors.Is(errorRes, ErrInvalidKey))
+
+	r = request()
+	r.Header.Set(snap_validator_headers.HeaderClientKey, "other")
+	assert.True(t, errors.As(verifier.Verify(r, serviceCode), &errorRes))
+	assert.Equal(t, "4017300", errorRes.SnapCode)
+	assert.Equal(t, "Unauthorized X-CLIENT-KEY", errorRes.Message)
+}
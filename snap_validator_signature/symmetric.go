@@ -0,0 +1,120 @@
+package snap_validator_signature
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/apelweb15/snap-validator/snap_validator_headers"
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"net/http"
+	"strings"
+)
+
+// SecretStore looks up the client secret of a partner by X-PARTNER-ID. It
+// returns ErrUnknownPartner when the partner is not registered.
+type SecretStore interface {
+	ClientSecret(ctx context.Context, partnerID string) (string, error)
+}
+
+// SecretStoreFunc adapts a function to SecretStore.
+type SecretStoreFunc func(ctx context.Context, partnerID string) (string, error)
+
+func (f SecretStoreFunc) ClientSecret(ctx context.Context, partnerID string) (string, error) {
+	return f(ctx, partnerID)
+}
+
+// StaticSecrets is a SecretStore backed by a map of partner ID to secret.
+type StaticSecrets map[string]string
+
+func (s StaticSecrets) ClientSecret(ctx context.Context, partnerID string) (string, error) {
+	if secret, ok := s[partnerID]; ok {
+		return secret, nil
+	}
+	return "", ErrUnknownPartner
+}
+
+// BodyHash returns the lowercase hex SHA-256 of the minified JSON body. An
+// empty body hashes as the empty string.
+func BodyHash(body []byte) (string, error) {
+	var minified bytes.Buffer
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Compact(&minified, body); err != nil {
+			return "", err
+		}
+	}
+	sum := sha256.Sum256(minified.Bytes())
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// SymmetricStringToSign builds
+// method:path:accessToken:lowerhex(sha256(minify(body))):timestamp.
+// The method is upper-cased and a "Bearer " prefix is removed from
+// accessToken.
+func SymmetricStringToSign(method string, path string, accessToken string, body []byte, timestamp string) (string, error) {
+	bodyHash, err := BodyHash(body)
+	if err != nil {
+		return "", err
+	}
+	accessToken = strings.TrimPrefix(accessToken, "Bearer ")
+	return strings.Join([]string{strings.ToUpper(method), path, accessToken, bodyHash, timestamp}, ":"), nil
+}
+
+// SignSymmetric returns base64(HMAC_SHA512(clientSecret, stringToSign)).
+func SignSymmetric(clientSecret string, stringToSign string) string {
+	mac := hmac.New(sha512.New, []byte(clientSecret))
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// SymmetricVerifier verifies the X-SIGNATURE of transaction requests.
+type SymmetricVerifier struct {
+	secrets SecretStore
+}
+
+// NewSymmetricVerifier returns a verifier that looks up secrets in secrets.
+func NewSymmetricVerifier(secrets SecretStore) *SymmetricVerifier {
+	return &SymmetricVerifier{secrets: secrets}
+}
+
+// Verify checks the X-SIGNATURE of r against its method, path with query,
+// Authorization, body and X-TIMESTAMP, using the secret of X-PARTNER-ID.
+// The body stays readable for the next handler.
+func (v *SymmetricVerifier) Verify(r *http.Request, serviceCode snap_validator_services.ServiceCode) error {
+	if err := serviceCode.Validate(); err != nil {
+		return err
+	}
+	ctx := r.Context()
+	body, err := readBody(r)
+	if err != nil {
+		return newError(ctx, "40000", "", "invalid_body", serviceCode, err)
+	}
+	signature := r.Header.Get(snap_validator_headers.HeaderSignature)
+	if signature == "" {
+		return newError(ctx, "40002", snap_validator_headers.HeaderSignature, "required", serviceCode, nil)
+	}
+	partnerID := r.Header.Get(snap_validator_headers.HeaderPartnerID)
+	if partnerID == "" {
+		return newError(ctx, "40002", snap_validator_headers.HeaderPartnerID, "required", serviceCode, nil)
+	}
+	timestamp, err := timestampOf(ctx, r, serviceCode)
+	if err != nil {
+		return err
+	}
+	secret, err := v.secrets.ClientSecret(ctx, partnerID)
+	if err != nil {
+		return lookupError(ctx, err, snap_validator_headers.HeaderPartnerID, serviceCode)
+	}
+	stringToSign, err := SymmetricStringToSign(r.Method, r.URL.RequestURI(), r.Header.Get(snap_validator_headers.HeaderAuthorization), body, timestamp)
+	if err != nil {
+		return newError(ctx, "40000", "", "invalid_body", serviceCode, err)
+	}
+	if !hmac.Equal([]byte(SignSymmetric(secret, stringToSign)), []byte(signature)) {
+		return newError(ctx, "40101", snap_validator_headers.HeaderSignature, "signature", serviceCode, nil)
+	}
+	return nil
+}
@@ -1,10 +1,14 @@
 package snap_validator
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"github.com/apelweb15/snap-validator/internal/validator"
 	"github.com/apelweb15/snap-validator/snap_validator_errors"
+	"github.com/apelweb15/snap-validator/snap_validator_services"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -86,3 +90,643 @@ func TestRequired(t *testing.T) {
 	fmt.Println(errNumber)
 	assert.Error(t, errNumber, "Number required successfully")
 }
+
+func TestCollectAllErrors(t *testing.T) {
+	req := Request{
+		PartnerServiceId: "   1114",
+		CustomerNo:       "12a",
+		TotalAmount: TotalAmount{
+			Value:    "25000",
+			Currency: "IDR",
+		},
+		ExpiredDate: "2024-12-31T23:59:59+07:00",
+		BillDetails: []BillDetail{{BillCode: "1x"}},
+	}
+	v := New(WithCollectAllErrors())
+	err := v.ValidateStruct(req, "25")
+
+	var errs snap_validator_errors.ValidationErrors
+	assert.True(t, errors.As(err, &errs))
+
+	var paths []string
+	for _, e := range errs {
+		paths = append(paths, e.Path)
+	}
+	assert.Equal(t, []string{
+		"totalAmount.value",
+		"partnerServiceId",
+		"customerNo",
+		"expiredDate",
+		"trxId",
+		"billDetails.0.billCode",
+		"billDetails.0.billAmount",
+	}, paths)
+	assert.Equal(t, "4002501", errs.SnapCode())
+	assert.Equal(t, errs[0], errs.Primary())
+
+	err = New().ValidateStruct(req, "25")
+	var errorRes *snap_validator_errors.ErrorValidation
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, errs.Primary().Message, errorRes.Message)
+}
+
+type OptionalRequest struct {
+	PartnerServiceId string        `json:"partnerServiceId" snapValidator:"required|numeric"`
+	TotalAmount      *TotalAmount  `json:"totalAmount" snapValidator:"required"`
+	FeeAmount        *TotalAmount  `json:"feeAmount"`
+	Reference        *string       `json:"reference" snapValidator:"max_length:4"`
+	AdditionalInfo   interface{}   `json:"additionalInfo"`
+	Items            []*BillDetail `json:"items"`
+}
+
+func TestPointerAndInterfaceFields(t *testing.T) {
+	v := New()
+	var errorRes *snap_validator_errors.ErrorValidation
+
+	assert.NotPanics(t, func() {
+		err := v.ValidateStruct(&OptionalRequest{}, "25")
+		assert.True(t, errors.As(err, &errorRes))
+		assert.Equal(t, "partnerServiceId", errorRes.Path)
+	})
+
+	err := v.ValidateStruct(&OptionalRequest{PartnerServiceId: "1"}, "25")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "4002502", errorRes.SnapCode)
+	assert.Equal(t, "totalAmount", errorRes.Path)
+
+	reference := "12345"
+	req := &OptionalRequest{
+		PartnerServiceId: "1",
+		TotalAmount:      &TotalAmount{Value: "1000.00", Currency: "IDR"},
+		Reference:        &reference,
+	}
+	err = v.ValidateStruct(req, "25")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "reference", errorRes.Path)
+
+	reference = "1234"
+	req.FeeAmount = &TotalAmount{}
+	err = v.ValidateStruct(req, "25")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "feeAmount.value", errorRes.Path)
+
+	req.FeeAmount = nil
+	req.AdditionalInfo = TotalAmount{Value: "1000.00", Currency: "USD"}
+	err = v.ValidateStruct(req, "25")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "additionalInfo.currency", errorRes.Path)
+
+	req.AdditionalInfo = nil
+	req.Items = []*BillDetail{nil, {BillCode: "01"}}
+	err = v.ValidateStruct(req, "25")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "items.1.billAmount", errorRes.Path)
+
+	err = v.ValidateStruct((*OptionalRequest)(nil), "25")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "40000", errorRes.Code)
+}
+
+func TestCustomMessages(t *testing.T) {
+	req := Request{
+		PartnerServiceId: "12345678",
+		CustomerNo:       "1231231231",
+		TotalAmount: TotalAmount{
+			Value:    "25000.00",
+			Currency: "IDR",
+		},
+		ExpiredDate: "2099-12-31T23:59:59+07:00",
+		TrxId:       "ishdfi-8u8u9kk",
+		BillDetails: []BillDetail{
+			{BillCode: "01", BillAmount: TotalAmount{Value: "1.00", Currency: "IDR"}},
+			{BillCode: "123", BillAmount: TotalAmount{Value: "1.00", Currency: "IDR"}},
+		},
+	}
+	var errorRes *snap_validator_errors.ErrorValidation
+
+	v := New(WithCustomMessage("billDetails.*.billCode", "max_length", "bill {index} ({label}) exceeds {param} characters"))
+	err := v.ValidateStruct(req, "25")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "bill 1 (billDetails.1.billCode) exceeds 2 characters", errorRes.Message)
+	assert.Equal(t, "4002501", errorRes.SnapCode)
+
+	v = New(WithCustomMessages(map[string]string{
+		"BillCode:max_length":               "generic",
+		"BillDetails.*.BillCode:max_length": "bill code #{index0} too long",
+	}))
+	err = v.ValidateStruct(req, "25")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "bill code #1 too long", errorRes.Message)
+
+	v = New(WithCustomMessage("BillDetails.*.BillCode", "max_length", "bill %d code too long"))
+	err = v.ValidateStruct(req, "25")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "bill 1 code too long", errorRes.Message)
+
+	v = New(WithCustomMessage("BillCode", "max_length", "generic"))
+	err = v.ValidateStruct(req, "25")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "generic", errorRes.Message)
+}
+
+type VaRequest struct {
+	VirtualAccountNo string `json:"virtualAccountNo" snapValidator:"required|va_prefix:[12345,67890]"`
+	CustomerName     string `json:"customerName" snapValidator:"blacklist"`
+}
+
+func TestRegisterRule(t *testing.T) {
+	v := New()
+	var received RuleContext
+	err := v.RegisterRule("va_prefix", func(ctx RuleContext) error {
+		received = ctx
+		for _, prefix := range ctx.Params {
+			if strings.HasPrefix(ctx.Value.(string), prefix) {
+				return nil
+			}
+		}
+		return errors.New("unknown prefix")
+	})
+	assert.NoError(t, err)
+	err = v.RegisterRule("blacklist", func(ctx RuleContext) error {
+		if ctx.Value == "" {
+			return nil
+		}
+		return &snap_validator_errors.ErrorValidation{Code: "40319"}
+	})
+	assert.NoError(t, err)
+	assert.Error(t, v.RegisterRule("required", func(ctx RuleContext) error { return nil }))
+	assert.Error(t, v.RegisterRule("bad:name", func(ctx RuleContext) error { return nil }))
+
+	assert.NoError(t, v.ValidateStruct(VaRequest{VirtualAccountNo: "6789000001"}, "24"))
+	assert.Equal(t, []string{"12345", "67890"}, received.Params)
+	assert.Equal(t, "virtualAccountNo", received.Path)
+	assert.Equal(t, "24", received.ServiceCode)
+
+	var errorRes *snap_validator_errors.ErrorValidation
+	err = v.ValidateStruct(VaRequest{VirtualAccountNo: "1111100001"}, "24")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "4002401", errorRes.SnapCode)
+	assert.Equal(t, "Invalid Field Format virtualAccountNo", errorRes.Message)
+
+	err = v.ValidateStruct(VaRequest{VirtualAccountNo: "1234500001", CustomerName: "x"}, "24")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "4032419", errorRes.SnapCode)
+	assert.Equal(t, "Merchant Blacklisted customerName", errorRes.Message)
+}
+
+func benchmarkRequest() Request {
+	return Request{
+		PartnerServiceId: "12345678",
+		CustomerNo:       "1231231231",
+		TotalAmount: TotalAmount{
+			Value:    "25000.00",
+			Currency: "IDR",
+		},
+		ExpiredDate:         "2099-12-31T23:59:59+07:00",
+		TrxId:               "ishdfi-8u8u9kk",
+		VirtualAccountEmail: "customer@example.com",
+		BillDetails: []BillDetail{
+			{BillCode: "01", BillNo: "123456", BillName: "Bill 1", BillAmount: TotalAmount{Value: "10000.00", Currency: "IDR"}},
+			{BillCode: "02", BillNo: "123457", BillName: "Bill 2", BillAmount: TotalAmount{Value: "15000.00", Currency: "IDR"}},
+		},
+	}
+}
+
+func BenchmarkValidateStruct(b *testing.B) {
+	v := New()
+	req := benchmarkRequest()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := v.ValidateStruct(req, "25"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkValidateStructUncached compiles the plans on every call, which is
+// the cost of validating before plans were cached.
+func BenchmarkValidateStructUncached(b *testing.B) {
+	v := New()
+	req := benchmarkRequest()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		validator.ResetPlanCache()
+		if err := v.ValidateStruct(req, "25"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValidateStructParallel(b *testing.B) {
+	v := New()
+	req := benchmarkRequest()
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if err := v.ValidateStruct(req, "25"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+type LintRequest struct {
+	PartnerServiceId string       `json:"partnerServiceId" snapValidator:"required|max_length:abc"`
+	Amount           int          `json:"amount" snapValidator:"required|numeric"`
+	Channel          string       `json:"channel" snapValidator:"in_data|required:yes|channel_code"`
+	Bills            []BillDetail `json:"bills"`
+	Info             *LintRequest `json:"info"`
+	Ignored          string       `json:"ignored" snapvalidator:"required"`
+	CustomerNo       string       `json:"customerNo" snapValidator:"min_length"`
+}
+
+func TestCompile(t *testing.T) {
+	v := New()
+	assert.NoError(t, v.Compile(TotalAmount{}))
+
+	err := v.Compile(&LintRequest{})
+	var report *CompileReport
+	assert.True(t, errors.As(err, &report))
+
+	var found []string
+	for _, issue := range report.Issues {
+		found = append(found, string(issue.Kind)+" "+issue.Path+" "+issue.Rule)
+	}
+	assert.Equal(t, []string{
+		"malformed_param partnerServiceId max_length",
+		"incompatible_kind amount numeric",
+		"malformed_param channel in_data",
+		"malformed_param channel required",
+		"unknown_rule channel channel_code",
+		"suspicious_tag bills.*.billDescription.indonesia ",
+		"suspicious_tag bills.*.billDescription.english ",
+		"suspicious_tag ignored ",
+		"malformed_param customerNo min_length",
+	}, found)
+
+	var errorRes *snap_validator_errors.ErrorValidation
+	err = v.ValidateStruct(LintRequest{PartnerServiceId: "12345678"}, "25")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "5002500", errorRes.SnapCode)
+	assert.Equal(t, "General Error", errorRes.Message)
+	assert.Equal(t, "max_length", errorRes.Rule)
+	parsed, parseErr := snap_validator_errors.ParseResponseCode(errorRes.SnapCode)
+	assert.NoError(t, parseErr)
+	assert.True(t, parsed.Known)
+
+	type MissingParam struct {
+		CustomerNo string `json:"customerNo" snapValidator:"min_length"`
+	}
+	err = v.ValidateStruct(MissingParam{CustomerNo: "123"}, "25")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "5002500", errorRes.SnapCode)
+	assert.Equal(t, "min_length requires an integer parameter", errorRes.Detail)
+
+	assert.NoError(t, v.RegisterRule("channel_code", func(ctx RuleContext) error { return nil }))
+	assert.Panics(t, func() { v.MustRegister(LintRequest{}) })
+	assert.NoError(t, v.RegisterRule("va_prefix", func(ctx RuleContext) error { return nil }))
+	assert.NoError(t, v.RegisterRule("blacklist", func(ctx RuleContext) error { return nil }))
+	assert.NotPanics(t, func() { v.MustRegister(TotalAmount{}, &VaRequest{}) })
+}
+
+type ConditionalRequest struct {
+	VirtualAccountTrxType string       `json:"virtualAccountTrxType" snapValidator:"required|in_data:[C,O,V]"`
+	TotalAmount           *TotalAmount `json:"totalAmount" snapValidator:"required_if:virtualAccountTrxType,C,V"`
+	InquiryRequestId      string       `json:"inquiryRequestId"`
+	BillDetails           []BillDetail `json:"billDetails" snapValidator:"required_with:inquiryRequestId"`
+	CustomerNo            string       `json:"customerNo" snapValidator:"required_without:virtualAccountNo"`
+	VirtualAccountNo      string       `json:"virtualAccountNo"`
+}
+
+func TestConditionalRequired(t *testing.T) {
+	v := New()
+	var errorRes *snap_validator_errors.ErrorValidation
+
+	req := ConditionalRequest{VirtualAccountTrxType: "O", VirtualAccountNo: "123"}
+	assert.NoError(t, v.ValidateStruct(req, "27"))
+
+	req.VirtualAccountTrxType = "C"
+	err := v.ValidateStruct(req, "27")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "4002702", errorRes.SnapCode)
+	assert.Equal(t, "Missing Mandatory Field totalAmount", errorRes.Message)
+
+	req.TotalAmount = &TotalAmount{Value: "10000.00", Currency: "IDR"}
+	req.InquiryRequestId = "abc"
+	err = v.ValidateStruct(req, "27")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "billDetails", errorRes.Path)
+
+	req.InquiryRequestId = ""
+	req.VirtualAccountNo = ""
+	err = v.ValidateStruct(req, "27")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "customerNo", errorRes.Path)
+
+	err = v.Compile(struct {
+		A string `json:"a" snapValidator:"required_if:b"`
+		B string `json:"b" snapValidator:"required_with:c"`
+	}{})
+	assert.ErrorContains(t, err, "a: required_if expects a field and at least one value")
+	assert.ErrorContains(t, err, "b: required_with references unknown field \"c\"")
+}
+
+type StatementRequest struct {
+	TrxDateTime  string         `json:"trxDateTime" snapValidator:"required|iso_date"`
+	FromDateTime string         `json:"fromDateTime" snapValidator:"iso_date|before_field:toDateTime"`
+	ToDateTime   string         `json:"toDateTime" snapValidator:"iso_date"`
+	TotalAmount  TotalAmount    `json:"totalAmount"`
+	PaidAmount   TotalAmount    `json:"paidAmount" snapValidator:"eq_field:totalAmount"`
+	FeeAmount    string         `json:"feeAmount" snapValidator:"amount|gt_field:minFee"`
+	MinFee       string         `json:"minFee"`
+	Detail       *StatementItem `json:"detail"`
+}
+
+type StatementItem struct {
+	ExpiredDate string `json:"expiredDate" snapValidator:"after_field:$.trxDateTime"`
+}
+
+func TestCompareFields(t *testing.T) {
+	v := New()
+	var errorRes *snap_validator_errors.ErrorValidation
+	req := StatementRequest{
+		TrxDateTime:  "2024-06-01T10:00:00+07:00",
+		FromDateTime: "2024-06-01T00:00:00+07:00",
+		ToDateTime:   "2024-06-01T09:00:00+07:00",
+		TotalAmount:  TotalAmount{Value: "10000.00", Currency: "IDR"},
+		PaidAmount:   TotalAmount{Value: "10000.00", Currency: "IDR"},
+		FeeAmount:    "2500.00",
+		MinFee:       "1000",
+		Detail:       &StatementItem{ExpiredDate: "2024-06-01T04:00:01+01:00"},
+	}
+	assert.NoError(t, v.ValidateStruct(req, "14"))
+	assert.NoError(t, v.Compile(req))
+
+	req.ToDateTime = "2024-05-31T23:00:00+07:00"
+	err := v.ValidateStruct(req, "14")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "Invalid Field Format fromDateTime must be before toDateTime", errorRes.Message)
+	assert.Equal(t, "4001401", errorRes.SnapCode)
+
+	req.ToDateTime = ""
+	req.PaidAmount.Value = "9000.00"
+	err = v.ValidateStruct(req, "14")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "paidAmount", errorRes.Path)
+
+	req.PaidAmount.Value = "10000.00"
+	req.MinFee = "2500"
+	err = v.ValidateStruct(req, "14")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "Invalid Field Format feeAmount must be greater than minFee", errorRes.Message)
+
+	req.MinFee = ""
+	req.Detail.ExpiredDate = "2024-06-01T04:00:00+01:00"
+	err = v.ValidateStruct(req, "14")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "Invalid Field Format detail.expiredDate must be after trxDateTime", errorRes.Message)
+
+	err = v.Compile(struct {
+		A string `json:"a" snapValidator:"eq_field:b.c"`
+	}{})
+	assert.ErrorContains(t, err, "eq_field references unknown field \"b.c\"")
+
+	err = v.Compile(struct {
+		A bool `json:"a" snapValidator:"gt_field:b"`
+		B bool `json:"b"`
+	}{})
+	assert.ErrorContains(t, err, "gt_field only applies to strings, numbers and amounts, field is bool")
+
+	type rawAmount struct {
+		Value    string `json:"value"`
+		Currency string `json:"currency"`
+	}
+	assert.NoError(t, v.ValidateStruct(struct {
+		A rawAmount `json:"a" snapValidator:"eq_field:b"`
+		B rawAmount `json:"b"`
+	}{A: rawAmount{"10000.0", "IDR"}, B: rawAmount{"10000.00", "IDR"}}, "14"))
+
+	err = v.ValidateStruct(struct {
+		A rawAmount `json:"a" snapValidator:"eq_field:b"`
+		B rawAmount `json:"b"`
+	}{A: rawAmount{"10000.00", "USD"}, B: rawAmount{"10000.00", "IDR"}}, "14")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "Invalid Field Format a must equal b", errorRes.Message)
+
+	err = v.ValidateStruct(struct {
+		A rawAmount `json:"a" snapValidator:"gt_field:b"`
+		B rawAmount `json:"b"`
+	}{A: rawAmount{"20000.00", "USD"}, B: rawAmount{"10000.00", "IDR"}}, "14")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "Invalid Field Format a must be greater than b", errorRes.Message)
+
+	err = v.ValidateStruct(struct {
+		A string `json:"a" snapValidator:"gt_field:b"`
+		B string `json:"b"`
+	}{A: "b", B: "a"}, "14")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "Invalid Field Format a must be greater than b", errorRes.Message)
+}
+
+type TagInfo struct {
+	Tags  []string `json:"tags" snapValidator:"required|min_items:1|max_items:3|unique|dive|required|max_length:5|alpha_numeric"`
+	Codes []int    `json:"codes" snapValidator:"dive|required"`
+}
+
+type TaggedRequest struct {
+	AdditionalInfo TagInfo `json:"additionalInfo"`
+}
+
+func TestDive(t *testing.T) {
+	v := New()
+	var errorRes *snap_validator_errors.ErrorValidation
+	req := TaggedRequest{AdditionalInfo: TagInfo{Tags: []string{"a", "b"}, Codes: []int{1, 2}}}
+	assert.NoError(t, v.ValidateStruct(req, "24"))
+	assert.NoError(t, v.Compile(req))
+
+	req.AdditionalInfo.Tags = []string{"a", "b", "c", "toolong"}
+	err := v.ValidateStruct(req, "24")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "additionalInfo.tags", errorRes.Path)
+
+	req.AdditionalInfo.Tags = []string{"a", "a"}
+	err = v.ValidateStruct(req, "24")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "additionalInfo.tags", errorRes.Path)
+
+	req.AdditionalInfo.Tags = []string{"a", "b", "toolong"}
+	err = v.ValidateStruct(req, "24")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "Invalid Field Format additionalInfo.tags.2", errorRes.Message)
+
+	req.AdditionalInfo.Tags = []string{"a", "b"}
+	req.AdditionalInfo.Codes = []int{1, 0}
+	err = New(WithCustomMessage("additionalInfo.codes.*", "required", "code {index} is empty")).ValidateStruct(req, "24")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "additionalInfo.codes.1", errorRes.Path)
+	assert.Equal(t, "code 1 is empty", errorRes.Message)
+	assert.Equal(t, "4002402", errorRes.SnapCode)
+
+	err = v.Compile(struct {
+		Name  string   `json:"name" snapValidator:"min_items:1|dive|required"`
+		Codes []string `json:"codes" snapValidator:"max_length:2|dive|unique"`
+	}{})
+	assert.ErrorContains(t, err, "name: min_items only applies to slices, arrays and maps")
+	assert.ErrorContains(t, err, "name: dive only applies to slices, arrays and maps")
+	assert.ErrorContains(t, err, "codes: max_length only applies to strings")
+	assert.ErrorContains(t, err, "codes.*: unique only applies to slices, arrays and maps")
+}
+
+type DeviceInfo struct {
+	Os string `json:"os" snapValidator:"required|in_data:[android,ios]"`
+}
+
+type MapRequest struct {
+	AdditionalInfo map[string]string     `json:"additionalInfo" snapValidator:"required_keys:[deviceId,channel]|keys|max_length:10|alpha_numeric|values|max_length:5"`
+	Devices        map[string]DeviceInfo `json:"devices"`
+	Extra          map[string]any        `json:"extra" snapValidator:"dive|string"`
+}
+
+func TestMapFields(t *testing.T) {
+	v := New()
+	var errorRes *snap_validator_errors.ErrorValidation
+	req := MapRequest{
+		AdditionalInfo: map[string]string{"deviceId": "12345", "channel": "web"},
+		Devices:        map[string]DeviceInfo{"main": {Os: "ios"}},
+		Extra:          map[string]any{"note": "ok", "count": 1},
+	}
+	assert.NoError(t, v.ValidateStruct(req, "24"))
+	assert.NoError(t, v.Compile(req))
+
+	delete(req.AdditionalInfo, "channel")
+	err := v.ValidateStruct(req, "24")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "Missing Mandatory Field additionalInfo.channel", errorRes.Message)
+
+	req.AdditionalInfo["channel"] = "web"
+	req.AdditionalInfo["deviceId"] = "123456"
+	err = v.ValidateStruct(req, "24")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "additionalInfo.deviceId", errorRes.Path)
+
+	req.AdditionalInfo["deviceId"] = "12345"
+	req.AdditionalInfo["device-type"] = "x"
+	err = v.ValidateStruct(req, "24")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "additionalInfo.device-type", errorRes.Path)
+
+	delete(req.AdditionalInfo, "device-type")
+	req.Devices["backup"] = DeviceInfo{Os: "symbian"}
+	err = v.ValidateStruct(req, "24")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "devices.backup.os", errorRes.Path)
+
+	delete(req.Devices, "backup")
+	req.Extra["note"] = "<script>"
+	err = v.ValidateStruct(req, "24")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "extra.note", errorRes.Path)
+
+	err = v.Compile(struct {
+		Tags []string `json:"tags" snapValidator:"required_keys:[a]|keys|required"`
+	}{})
+	assert.ErrorContains(t, err, "tags: required_keys only applies to maps")
+	assert.ErrorContains(t, err, "tags: keys only applies to maps")
+}
+
+func TestInvalidServiceCode(t *testing.T) {
+	err := New().ValidateStruct(TotalAmount{Value: "1.00", Currency: "IDR"}, "251")
+	assert.True(t, errors.Is(err, snap_validator_services.ErrInvalidServiceCode))
+	assert.NoError(t, ValidateStructService(TotalAmount{Value: "1.00", Currency: "IDR"}, snap_validator_services.VirtualAccountPayment))
+
+	// An empty service code keeps the five digit codes of earlier versions.
+	var errorRes *snap_validator_errors.ErrorValidation
+	err = ValidateStruct(TotalAmount{Currency: "IDR"}, "")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "40002", errorRes.SnapCode)
+}
+
+func TestLocalizedMessages(t *testing.T) {
+	req := StatementRequest{
+		TrxDateTime:  "2024-06-01T10:00:00+07:00",
+		FromDateTime: "2024-06-02T00:00:00+07:00",
+		ToDateTime:   "2024-06-01T09:00:00+07:00",
+	}
+	var errorRes *snap_validator_errors.ErrorValidation
+
+	err := New(WithLocale(snap_validator_errors.LocaleIndonesian)).ValidateStruct(req, "14")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "Format Field Tidak Valid fromDateTime harus sebelum toDateTime", errorRes.Message)
+
+	v := New()
+	ctx := snap_validator_errors.ContextWithLocale(context.Background(), snap_validator_errors.LocaleIndonesian)
+	err = v.ValidateStructContext(ctx, Request{}, "25")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "Field Wajib Tidak Diisi totalAmount", errorRes.Message)
+
+	err = v.ValidateStructContext(context.Background(), Request{}, "25")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "Missing Mandatory Field totalAmount", errorRes.Message)
+
+	messages := snap_validator_errors.NewMessages()
+	assert.NoError(t, messages.Load(strings.NewReader(`{"id": {"40002": "Data Wajib Belum Diisi"}, "jv": {"40002": "Data Kudu Diisi"}}`)))
+	err = New(WithMessages(messages), WithLocale("jv")).ValidateStruct(Request{}, "25")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "Data Kudu Diisi totalAmount", errorRes.Message)
+
+	err = New(WithMessages(messages), WithLocale("jv")).ValidateStruct(req, "14")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "Invalid Field Format fromDateTime must be before toDateTime", errorRes.Message)
+}
+
+func TestErrorDetail(t *testing.T) {
+	type Account struct {
+		AccountNo string `json:"accountNo" snapValidator:"required|numeric|max_length:8" snapMask:"true"`
+		Currency  string `json:"currency" snapValidator:"required|in_data:[IDR,USD]"`
+	}
+	var errorRes *snap_validator_errors.ErrorValidation
+
+	err := New().ValidateStruct(Account{AccountNo: "1234567890", Currency: "IDR"}, "11")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "Invalid Field Format accountNo", errorRes.Message)
+	assert.Equal(t, "max_length", errorRes.Rule)
+	assert.Equal(t, []string{"8"}, errorRes.Params)
+	assert.Equal(t, "******7890", errorRes.Value)
+	assert.Equal(t, "length must be at most 8", errorRes.Detail)
+
+	err = New(WithLocale(snap_validator_errors.LocaleIndonesian)).ValidateStruct(Account{AccountNo: "123", Currency: "EUR"}, "11")
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "in_data", errorRes.Rule)
+	assert.Equal(t, "EUR", errorRes.Value)
+	assert.Equal(t, "harus salah satu dari IDR, USD", errorRes.Detail)
+}
+
+func TestErrorsIs(t *testing.T) {
+	err := ValidateStruct(Request{}, "25")
+	wrapped := fmt.Errorf("inquiry: %w", err)
+	assert.True(t, errors.Is(wrapped, snap_validator_errors.ErrMissingMandatoryField))
+	assert.True(t, errors.Is(wrapped, snap_validator_errors.ErrBadRequest))
+	assert.False(t, errors.Is(wrapped, snap_validator_errors.ErrInvalidFieldFormat))
+	assert.False(t, errors.Is(wrapped, snap_validator_errors.ErrUnauthorized))
+
+	err = New(WithCollectAllErrors()).ValidateStruct(TotalAmount{Value: "1"}, "25")
+	var errorRes *snap_validator_errors.ErrorValidation
+	assert.True(t, errors.As(err, &errorRes))
+	assert.True(t, errors.Is(err, snap_validator_errors.ErrMissingMandatoryField))
+	assert.True(t, errors.Is(err, snap_validator_errors.ErrInvalidFieldFormat))
+
+	errNotRegistered := errors.New("partner not registered")
+	type Partner struct {
+		PartnerId string `json:"partnerId" snapValidator:"registered_partner"`
+	}
+	v := New()
+	assert.NoError(t, v.RegisterRule("registered_partner", func(ctx RuleContext) error {
+		return errNotRegistered
+	}))
+	err = v.ValidateStruct(Partner{PartnerId: "X"}, "25")
+	assert.True(t, errors.Is(err, errNotRegistered))
+	assert.True(t, errors.Is(err, snap_validator_errors.ErrInvalidFieldFormat))
+}
@@ -0,0 +1,184 @@
+// Package snap_validator_token provides the SNAP access token models and a
+// token issuer with pluggable storage. It is meant as a stand-in for a real
+// authorization server, e.g. to run end-to-end SNAP flows locally.
+//
+//	issuer := snap_validator_token.NewIssuer()
+//	http.Handle("/v1.0/access-token/b2b", issuer.Handler(nil))
+//	...
+//	if _, err := issuer.Validate(r.Context(), r.Header.Get("Authorization"), serviceCode); err != nil {
+//		snap_validator_errors.ResponseFromError(err, string(serviceCode)).Write(w)
+//	}
+package snap_validator_token
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/apelweb15/snap-validator/httpmw"
+	"github.com/apelweb15/snap-validator/snap_validator_errors"
+	"github.com/apelweb15/snap-validator/snap_validator_headers"
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"github.com/apelweb15/snap-validator/snap_validator_signature"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTTL is the lifetime of issued tokens unless WithTTL is used.
+const DefaultTTL = 15 * time.Minute
+
+// Issuer issues and validates Bearer tokens. Create it with NewIssuer.
+type Issuer struct {
+	store Store
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+// IssuerOption configures an Issuer created with NewIssuer.
+type IssuerOption func(*Issuer)
+
+// WithStore keeps tokens in store instead of a MemoryStore, e.g. to share
+// them between instances.
+func WithStore(store Store) IssuerOption {
+	return func(i *Issuer) {
+		i.store = store
+	}
+}
+
+// WithTTL sets the lifetime of issued tokens.
+func WithTTL(ttl time.Duration) IssuerOption {
+	return func(i *Issuer) {
+		i.ttl = ttl
+	}
+}
+
+// WithClock replaces time.Now, e.g. in tests.
+func WithClock(now func() time.Time) IssuerOption {
+	return func(i *Issuer) {
+		i.now = now
+	}
+}
+
+// NewIssuer returns an Issuer backed by a MemoryStore with DefaultTTL.
+func NewIssuer(opts ...IssuerOption) *Issuer {
+	i := &Issuer{ttl: DefaultTTL, now: time.Now}
+	for _, opt := range opts {
+		opt(i)
+	}
+	if i.store == nil {
+		store := NewMemoryStore()
+		store.now = i.now
+		i.store = store
+	}
+	return i
+}
+
+// Issue creates and stores a token for clientKey.
+func (i *Issuer) Issue(ctx context.Context, clientKey string) (Token, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return Token{}, err
+	}
+	now := i.now()
+	token := Token{
+		Value:     base64.RawURLEncoding.EncodeToString(raw),
+		ClientKey: clientKey,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(i.ttl),
+	}
+	if err := i.store.Save(ctx, token); err != nil {
+		return Token{}, err
+	}
+	return token, nil
+}
+
+// Response renders token as the access token response, with the message in
+// the locale of ctx.
+func (i *Issuer) Response(ctx context.Context, token Token) AccessTokenResponse {
+	locale, _ := snap_validator_errors.LocaleFromContext(ctx)
+	return AccessTokenResponse{
+		ResponseCode:    snap_validator_errors.SnapCode("20000", string(snap_validator_services.AccessTokenB2B)),
+		ResponseMessage: snap_validator_errors.GetSnapMessageLocale("20000", locale),
+		AccessToken:     token.Value,
+		TokenType:       TokenTypeBearer,
+		ExpiresIn:       strconv.Itoa(int(token.ExpiresAt.Sub(token.IssuedAt).Seconds())),
+	}
+}
+
+// Validate checks an Authorization header value, e.g. "Bearer abc", and
+// returns its token. A missing or malformed header and an expired token are
+// reported as 40101 Invalid Token (B2B); a token the store does not know,
+// e.g. a revoked one, as 40103 Token Not Found (B2B). Messages follow the
+// locale of ctx set with snap_validator_errors.ContextWithLocale.
+func (i *Issuer) Validate(ctx context.Context, authorization string, serviceCode snap_validator_services.ServiceCode) (Token, error) {
+	if err := serviceCode.Validate(); err != nil {
+		return Token{}, err
+	}
+	authorization = strings.TrimSpace(authorization)
+	if authorization == "" {
+		return Token{}, snap_validator_errors.NewErrorSnapLocale(ctx, "40101", "", string(serviceCode), "", nil, nil)
+	}
+	value, ok := strings.CutPrefix(authorization, TokenTypeBearer+" ")
+	if !ok || value == "" {
+		return Token{}, snap_validator_errors.NewErrorSnapLocale(ctx, "40101", "", string(serviceCode), "", nil, nil)
+	}
+	token, err := i.store.Get(ctx, value)
+	if errors.Is(err, ErrTokenNotFound) {
+		return Token{}, snap_validator_errors.NewErrorSnapLocale(ctx, "40103", "", string(serviceCode), "", nil, err)
+	}
+	if err != nil {
+		return Token{}, snap_validator_errors.NewErrorSnapLocale(ctx, "50000", "", string(serviceCode), "", nil, err)
+	}
+	if token.Expired(i.now()) {
+		return Token{}, snap_validator_errors.NewErrorSnapLocale(ctx, "40101", "", string(serviceCode), "", nil, nil)
+	}
+	return token, nil
+}
+
+// Revoke removes a token so that it no longer validates.
+func (i *Issuer) Revoke(ctx context.Context, value string) error {
+	return i.store.Delete(ctx, value)
+}
+
+// Handler serves POST /v1.0/access-token/b2b: it validates the headers, the
+// X-SIGNATURE when verifier is not nil, and the body, then issues a token
+// for X-CLIENT-KEY.
+func (i *Issuer) Handler(verifier *snap_validator_signature.AsymmetricVerifier) http.Handler {
+	serviceCode := snap_validator_services.AccessTokenB2B
+	headers := snap_validator_headers.New(snap_validator_headers.WithRequiredHeaders(snap_validator_headers.AccessTokenHeaders...))
+	issue := httpmw.New(serviceCode, func(w http.ResponseWriter, r *http.Request, req *AccessTokenRequest) {
+		token, err := i.Issue(r.Context(), r.Header.Get(snap_validator_headers.HeaderClientKey))
+		if err != nil {
+			_ = snap_validator_errors.ResponseFromError(err, string(serviceCode)).Write(w)
+			return
+		}
+		writeJSON(w, i.Response(r.Context(), token))
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := headers.ValidateRequest(r, serviceCode); err != nil {
+			_ = snap_validator_errors.ResponseFromError(err, string(serviceCode)).Write(w)
+			return
+		}
+		if verifier != nil {
+			if err := verifier.Verify(r, serviceCode); err != nil {
+				_ = snap_validator_errors.ResponseFromError(err, string(serviceCode)).Write(w)
+				return
+			}
+		}
+		issue.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(raw)
+}
@@ -0,0 +1,61 @@
+package snap_validator_token
+
+import "github.com/apelweb15/snap-validator/snap_validator_services"
+
+// Grant types of the access token requests.
+const (
+	GrantTypeClientCredentials = "client_credentials"
+	GrantTypeAuthorizationCode = "AUTHORIZATION_CODE"
+	GrantTypeRefreshToken      = "REFRESH_TOKEN"
+)
+
+// TokenTypeBearer is the tokenType of issued tokens.
+const TokenTypeBearer = "Bearer"
+
+// AccessTokenRequest is the body of POST /v1.0/access-token/b2b.
+type AccessTokenRequest struct {
+	GrantType      string                 `json:"grantType" snapValidator:"required|in_data:[client_credentials]"`
+	AdditionalInfo map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (AccessTokenRequest) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.AccessTokenB2B
+}
+
+// AccessTokenResponse is the successful response of
+// POST /v1.0/access-token/b2b.
+type AccessTokenResponse struct {
+	ResponseCode    string                 `json:"responseCode"`
+	ResponseMessage string                 `json:"responseMessage"`
+	AccessToken     string                 `json:"accessToken"`
+	TokenType       string                 `json:"tokenType"`
+	ExpiresIn       string                 `json:"expiresIn"`
+	AdditionalInfo  map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+// AccessTokenB2B2CRequest is the body of POST /v1.0/access-token/b2b2c.
+// authCode is required for AUTHORIZATION_CODE and refreshToken for
+// REFRESH_TOKEN.
+type AccessTokenB2B2CRequest struct {
+	GrantType      string                 `json:"grantType" snapValidator:"required|in_data:[AUTHORIZATION_CODE,REFRESH_TOKEN]"`
+	AuthCode       string                 `json:"authCode" snapValidator:"required_if:grantType,AUTHORIZATION_CODE|max_length:256"`
+	RefreshToken   string                 `json:"refreshToken" snapValidator:"required_if:grantType,REFRESH_TOKEN|max_length:512"`
+	AdditionalInfo map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (AccessTokenB2B2CRequest) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.AccessTokenB2B2C
+}
+
+// AccessTokenB2B2CResponse is the successful response of
+// POST /v1.0/access-token/b2b2c.
+type AccessTokenB2B2CResponse struct {
+	ResponseCode           string                 `json:"responseCode"`
+	ResponseMessage        string                 `json:"responseMessage"`
+	AccessToken            string                 `json:"accessToken"`
+	TokenType              string                 `json:"tokenType"`
+	AccessTokenExpiryTime  string                 `json:"accessTokenExpiryTime"`
+	RefreshToken           string                 `json:"refreshToken,omitempty"`
+	RefreshTokenExpiryTime string                 `json:"refreshTokenExpiryTime,omitempty"`
+	AdditionalInfo         map[string]interface{} `json:"additionalInfo,omitempty"`
+}
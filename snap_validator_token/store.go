@@ -0,0 +1,95 @@
+package snap_validator_token
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrTokenNotFound is returned by stores that do not know a token.
+var ErrTokenNotFound = errors.New("snap_validator_token: token not found")
+
+// Token is an issued access token.
+type Token struct {
+	Value     string
+	ClientKey string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the token has expired at now.
+func (t Token) Expired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// Store keeps issued tokens. Get returns ErrTokenNotFound for unknown
+// tokens; it may also return it for expired ones.
+type Store interface {
+	Save(ctx context.Context, token Token) error
+	Get(ctx context.Context, value string) (Token, error)
+	Delete(ctx context.Context, value string) error
+}
+
+// MemoryStore is a Store for a single process, e.g. local end-to-end runs.
+// An expired token is removed when it is looked up; the others are swept
+// once as many tokens have been saved as the store holds, so Save stays
+// amortized O(1).
+type MemoryStore struct {
+	mu     sync.Mutex
+	tokens map[string]Token
+	now    func() time.Time
+	// saves counts the tokens saved since the last sweep.
+	saves int
+}
+
+// minSweepSaves keeps small stores from being swept on every save.
+const minSweepSaves = 64
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tokens: map[string]Token{}, now: time.Now}
+}
+
+func (s *MemoryStore) Save(ctx context.Context, token Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[token.Value] = token
+	s.saves++
+	if s.saves >= minSweepSaves && s.saves >= len(s.tokens) {
+		s.sweep(s.now())
+	}
+	return nil
+}
+
+// sweep removes the tokens expired at now.
+func (s *MemoryStore) sweep(now time.Time) {
+	for value, token := range s.tokens {
+		if token.Expired(now) {
+			delete(s.tokens, value)
+		}
+	}
+	s.saves = 0
+}
+
+// Get returns an expired token once, so the caller can tell it from an
+// unknown one, and removes it.
+func (s *MemoryStore) Get(ctx context.Context, value string) (Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, ok := s.tokens[value]
+	if !ok {
+		return Token{}, ErrTokenNotFound
+	}
+	if token.Expired(s.now()) {
+		delete(s.tokens, value)
+	}
+	return token, nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, value)
+	return nil
+}
@@ -0,0 +1,125 @@
+package snap_validator_token
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	snap_validator "github.com/apelweb15/snap-validator"
+	"github.com/apelweb15/snap-validator/snap_validator_errors"
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestModels(t *testing.T) {
+	var errorRes *snap_validator_errors.ErrorValidation
+	assert.NoError(t, snap_validator.ValidateModel(AccessTokenRequest{GrantType: GrantTypeClientCredentials}))
+
+	err := snap_validator.ValidateStructService(AccessTokenRequest{GrantType: "password"}, snap_validator_services.AccessTokenB2B)
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "4007301", errorRes.SnapCode)
+
+	assert.NoError(t, snap_validator.ValidateStructService(AccessTokenB2B2CRequest{GrantType: GrantTypeAuthorizationCode, AuthCode: "a6975f82"}, snap_validator_services.AccessTokenB2B2C))
+	err = snap_validator.ValidateStructService(AccessTokenB2B2CRequest{GrantType: GrantTypeRefreshToken, AuthCode: "a6975f82"}, snap_validator_services.AccessTokenB2B2C)
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "4007402", errorRes.SnapCode)
+	assert.Equal(t, "Missing Mandatory Field refreshToken", errorRes.Message)
+}
+
+func TestIssuer(t *testing.T) {
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	issuer := NewIssuer(WithClock(func() time.Time { return now }))
+	ctx := context.Background()
+	serviceCode := snap_validator_services.VirtualAccountPayment
+
+	token, err := issuer.Issue(ctx, "client")
+	assert.NoError(t, err)
+	assert.Equal(t, "900", issuer.Response(ctx, token).ExpiresIn)
+	assert.Equal(t, "2007300", issuer.Response(ctx, token).ResponseCode)
+
+	validated, err := issuer.Validate(ctx, "Bearer "+token.Value, serviceCode)
+	assert.NoError(t, err)
+	assert.Equal(t, "client", validated.ClientKey)
+
+	tests := []struct {
+		authorization string
+		snapCode      string
+		message       string
+	}{
+		{"", "4012501", "Invalid Token (B2B)"},
+		{token.Value, "4012501", "Invalid Token (B2B)"},
+		{"Bearer unknown", "4012503", "Token Not Found (B2B)"},
+	}
+	for _, tt := range tests {
+		var errorRes *snap_validator_errors.ErrorValidation
+		_, err = issuer.Validate(ctx, tt.authorization, serviceCode)
+		assert.True(t, errors.As(err, &errorRes))
+		assert.Equal(t, tt.snapCode, errorRes.SnapCode)
+		assert.Equal(t, tt.message, errorRes.Message)
+	}
+
+	var errorRes *snap_validator_errors.ErrorValidation
+	_, err = issuer.Validate(snap_validator_errors.ContextWithLocale(ctx, snap_validator_errors.LocaleIndonesian), "", serviceCode)
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "Token Tidak Valid (B2B)", errorRes.Message)
+
+	now = now.Add(DefaultTTL)
+	_, err = issuer.Validate(ctx, "Bearer "+token.Value, serviceCode)
+	assert.True(t, errors.Is(err, snap_validator_errors.ErrInvalidToken))
+
+	now = now.Add(-time.Minute)
+	assert.NoError(t, issuer.Revoke(ctx, token.Value))
+	_, err = issuer.Validate(ctx, "Bearer "+token.Value, serviceCode)
+	assert.True(t, errors.Is(err, snap_validator_errors.ErrTokenNotFound))
+}
+
+func TestMemoryStore(t *testing.T) {
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < minSweepSaves-1; i++ {
+		assert.NoError(t, store.Save(ctx, Token{Value: strconv.Itoa(i), ExpiresAt: now.Add(time.Minute)}))
+	}
+	now = now.Add(time.Minute)
+	token, err := store.Get(ctx, "0")
+	assert.NoError(t, err)
+	assert.True(t, token.Expired(now))
+	_, err = store.Get(ctx, "0")
+	assert.True(t, errors.Is(err, ErrTokenNotFound), "an expired token is removed on lookup")
+
+	assert.NoError(t, store.Save(ctx, Token{Value: "new", ExpiresAt: now.Add(time.Minute)}))
+	assert.Len(t, store.tokens, 1, "expired tokens are swept")
+}
+
+func TestHandler(t *testing.T) {
+	issuer := NewIssuer()
+	handler := issuer.Handler(nil)
+	request := func(body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/v1.0/access-token/b2b", strings.NewReader(body))
+		r.Header.Set("X-TIMESTAMP", time.Now().Format("2006-01-02T15:04:05-07:00"))
+		r.Header.Set("X-CLIENT-KEY", "client")
+		r.Header.Set("X-SIGNATURE", "signature")
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, r)
+		return recorder
+	}
+
+	recorder := request(`{"grantType":"client_credentials"}`)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var res AccessTokenResponse
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	assert.Equal(t, TokenTypeBearer, res.TokenType)
+	_, err := issuer.Validate(context.Background(), "Bearer "+res.AccessToken, snap_validator_services.VirtualAccountPayment)
+	assert.NoError(t, err)
+
+	recorder = request(`{}`)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"responseCode":"4007302"`)
+}
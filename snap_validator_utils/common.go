@@ -1,6 +1,9 @@
 package snap_validator_utils
 
-import "reflect"
+import (
+	"reflect"
+	"strings"
+)
 
 func KindIsNumeric(kind reflect.Kind) bool {
 	allowedKinds := []reflect.Kind{
@@ -46,3 +49,15 @@ func InArray(val interface{}, array interface{}) (exists bool, index int) {
 
 	return
 }
+
+// MaskValue hides all but the last four characters of value, e.g.
+// 1234567890 becomes ******7890. Values of four characters or less are
+// fully masked.
+func MaskValue(value string) string {
+	runes := []rune(value)
+	visible := 4
+	if len(runes) <= visible {
+		visible = 0
+	}
+	return strings.Repeat("*", len(runes)-visible) + string(runes[len(runes)-visible:])
+}
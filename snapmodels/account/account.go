@@ -0,0 +1,109 @@
+// Package account holds the SNAP account information request and response
+// models with their validation rules, following the BI SNAP specification.
+// Account numbers and card tokens are masked in ErrorValidation.Value.
+//
+//	err := snap_validator.ValidateModel(req)
+package account
+
+import (
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"github.com/apelweb15/snap-validator/snapmodels"
+)
+
+// BalanceInquiryRequest is the body of POST /v1.0/balance-inquiry.
+type BalanceInquiryRequest struct {
+	PartnerReferenceNo string                 `json:"partnerReferenceNo,omitempty" snapValidator:"max_length:64"`
+	BankCardToken      string                 `json:"bankCardToken,omitempty" snapValidator:"required_without:accountNo|max_length:128" snapMask:"true"`
+	AccountNo          string                 `json:"accountNo,omitempty" snapValidator:"max_length:16|numeric" snapMask:"true"`
+	BalanceTypes       []string               `json:"balanceTypes,omitempty" snapValidator:"dive|max_length:70"`
+	AdditionalInfo     map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (BalanceInquiryRequest) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.BalanceInquiry
+}
+
+// AccountInfo is the balance of one balance type.
+type AccountInfo struct {
+	BalanceType              string            `json:"balanceType,omitempty" snapValidator:"max_length:70"`
+	Amount                   snapmodels.Amount `json:"amount,omitempty"`
+	FloatAmount              snapmodels.Amount `json:"floatAmount,omitempty"`
+	HoldAmount               snapmodels.Amount `json:"holdAmount,omitempty"`
+	AvailableBalance         snapmodels.Amount `json:"availableBalance,omitempty"`
+	LedgerBalance            snapmodels.Amount `json:"ledgerBalance,omitempty"`
+	CurrentMultilateralLimit snapmodels.Amount `json:"currentMultilateralLimit,omitempty"`
+	RegistrationStatusCode   string            `json:"registrationStatusCode,omitempty" snapValidator:"max_length:4"`
+	Status                   string            `json:"status,omitempty" snapValidator:"max_length:4"`
+}
+
+// BalanceInquiryResponse is the response of POST /v1.0/balance-inquiry.
+type BalanceInquiryResponse struct {
+	ResponseCode       string                 `json:"responseCode" snapValidator:"required|min_length:7|max_length:7|numeric"`
+	ResponseMessage    string                 `json:"responseMessage" snapValidator:"required|max_length:150"`
+	ReferenceNo        string                 `json:"referenceNo,omitempty" snapValidator:"max_length:64"`
+	PartnerReferenceNo string                 `json:"partnerReferenceNo,omitempty" snapValidator:"max_length:64"`
+	AccountNo          string                 `json:"accountNo,omitempty" snapValidator:"max_length:16|numeric" snapMask:"true"`
+	Name               string                 `json:"name,omitempty" snapValidator:"max_length:140"`
+	AccountInfos       []AccountInfo          `json:"accountInfos,omitempty"`
+	AdditionalInfo     map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (BalanceInquiryResponse) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.BalanceInquiry
+}
+
+// BankStatementRequest is the body of POST /v1.0/bank-statement.
+type BankStatementRequest struct {
+	PartnerReferenceNo string                 `json:"partnerReferenceNo,omitempty" snapValidator:"max_length:64"`
+	BankCardToken      string                 `json:"bankCardToken,omitempty" snapValidator:"required_without:accountNo|max_length:128" snapMask:"true"`
+	AccountNo          string                 `json:"accountNo,omitempty" snapValidator:"max_length:16|numeric" snapMask:"true"`
+	FromDateTime       string                 `json:"fromDateTime,omitempty" snapValidator:"max_length:25|iso_date|before_field:toDateTime"`
+	ToDateTime         string                 `json:"toDateTime,omitempty" snapValidator:"max_length:25|iso_date"`
+	AdditionalInfo     map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (BankStatementRequest) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.BankStatement
+}
+
+// StatementBalance is the starting and ending balance of a statement.
+type StatementBalance struct {
+	Amount          snapmodels.Amount `json:"amount,omitempty"`
+	StartingBalance snapmodels.Amount `json:"startingBalance,omitempty"`
+	EndingBalance   snapmodels.Amount `json:"endingBalance,omitempty"`
+}
+
+// StatementEntries sums the credit or debit entries of a statement.
+type StatementEntries struct {
+	NumberOfEntries string            `json:"numberOfEntries,omitempty" snapValidator:"max_length:3|numeric"`
+	Amount          snapmodels.Amount `json:"amount,omitempty"`
+}
+
+// StatementDetail is one entry of a bank statement.
+type StatementDetail struct {
+	Amount                  snapmodels.Amount      `json:"amount,omitempty"`
+	OriginAmount            snapmodels.Amount      `json:"originAmount,omitempty"`
+	TransactionDate         string                 `json:"transactionDate,omitempty" snapValidator:"max_length:25|iso_date"`
+	Remark                  string                 `json:"remark,omitempty" snapValidator:"max_length:256"`
+	TransactionId           string                 `json:"transactionId,omitempty" snapValidator:"max_length:64"`
+	Type                    string                 `json:"type,omitempty" snapValidator:"in_data:[CREDIT,DEBIT]"`
+	TransactionDetailStatus string                 `json:"transactionDetailStatus,omitempty" snapValidator:"max_length:20"`
+	DetailInfo              map[string]interface{} `json:"detailInfo,omitempty"`
+}
+
+// BankStatementResponse is the response of POST /v1.0/bank-statement.
+type BankStatementResponse struct {
+	ResponseCode       string                 `json:"responseCode" snapValidator:"required|min_length:7|max_length:7|numeric"`
+	ResponseMessage    string                 `json:"responseMessage" snapValidator:"required|max_length:150"`
+	ReferenceNo        string                 `json:"referenceNo,omitempty" snapValidator:"max_length:64"`
+	PartnerReferenceNo string                 `json:"partnerReferenceNo,omitempty" snapValidator:"max_length:64"`
+	Balance            []StatementBalance     `json:"balance,omitempty"`
+	TotalCreditEntries StatementEntries       `json:"totalCreditEntries,omitempty"`
+	TotalDebitEntries  StatementEntries       `json:"totalDebitEntries,omitempty"`
+	DetailData         []StatementDetail      `json:"detailData,omitempty"`
+	AdditionalInfo     map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (BankStatementResponse) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.BankStatement
+}
@@ -0,0 +1,56 @@
+package account
+
+import (
+	"errors"
+	snap_validator "github.com/apelweb15/snap-validator"
+	"github.com/apelweb15/snap-validator/snap_validator_errors"
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"github.com/apelweb15/snap-validator/snapmodels"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestModelsCompile(t *testing.T) {
+	models := []snapmodels.Model{
+		BalanceInquiryRequest{}, BalanceInquiryResponse{},
+		BankStatementRequest{}, BankStatementResponse{},
+		InternalInquiryRequest{}, InternalInquiryResponse{},
+		ExternalInquiryRequest{}, ExternalInquiryResponse{},
+	}
+	for _, model := range models {
+		assert.NoError(t, snap_validator.Compile(model))
+	}
+	assert.Equal(t, snap_validator_services.ExternalAccountInquiry, ExternalInquiryRequest{}.ServiceCode())
+}
+
+func TestValidateModel(t *testing.T) {
+	var errorRes *snap_validator_errors.ErrorValidation
+	assert.NoError(t, snap_validator.ValidateModel(BalanceInquiryRequest{AccountNo: "115471119"}))
+
+	err := snap_validator.ValidateModel(BalanceInquiryRequest{})
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "4001102", errorRes.SnapCode)
+	assert.Equal(t, "bankCardToken", errorRes.Path)
+
+	err = snap_validator.ValidateModel(BankStatementRequest{
+		AccountNo:    "115471119",
+		FromDateTime: "2024-06-02T00:00:00+07:00",
+		ToDateTime:   "2024-06-01T00:00:00+07:00",
+	})
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "4001401", errorRes.SnapCode)
+	assert.Equal(t, "fromDateTime", errorRes.Path)
+
+	err = snap_validator.ValidateModel(&ExternalInquiryRequest{BeneficiaryAccountNo: "888801000157508"})
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "4001602", errorRes.SnapCode)
+	assert.Equal(t, "beneficiaryBankCode", errorRes.Path)
+}
+
+func TestMaskedValue(t *testing.T) {
+	var errorRes *snap_validator_errors.ErrorValidation
+	err := snap_validator.ValidateModel(BalanceInquiryRequest{AccountNo: "11547111912345678"})
+	assert.True(t, errors.As(err, &errorRes))
+	assert.Equal(t, "accountNo", errorRes.Path)
+	assert.Equal(t, "*************5678", errorRes.Value)
+}
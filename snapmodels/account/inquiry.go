@@ -0,0 +1,66 @@
+package account
+
+import "github.com/apelweb15/snap-validator/snap_validator_services"
+
+// InternalInquiryRequest is the body of
+// POST /v1.0/account-inquiry-internal.
+type InternalInquiryRequest struct {
+	PartnerReferenceNo   string                 `json:"partnerReferenceNo,omitempty" snapValidator:"max_length:64"`
+	BeneficiaryAccountNo string                 `json:"beneficiaryAccountNo" snapValidator:"required|max_length:34" snapMask:"true"`
+	AdditionalInfo       map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (InternalInquiryRequest) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.InternalAccountInquiry
+}
+
+// InternalInquiryResponse is the response of
+// POST /v1.0/account-inquiry-internal.
+type InternalInquiryResponse struct {
+	ResponseCode             string                 `json:"responseCode" snapValidator:"required|min_length:7|max_length:7|numeric"`
+	ResponseMessage          string                 `json:"responseMessage" snapValidator:"required|max_length:150"`
+	ReferenceNo              string                 `json:"referenceNo,omitempty" snapValidator:"max_length:64"`
+	PartnerReferenceNo       string                 `json:"partnerReferenceNo,omitempty" snapValidator:"max_length:64"`
+	BeneficiaryAccountName   string                 `json:"beneficiaryAccountName,omitempty" snapValidator:"max_length:100"`
+	BeneficiaryAccountNo     string                 `json:"beneficiaryAccountNo,omitempty" snapValidator:"max_length:34" snapMask:"true"`
+	BeneficiaryAccountStatus string                 `json:"beneficiaryAccountStatus,omitempty" snapValidator:"max_length:40"`
+	BeneficiaryAccountType   string                 `json:"beneficiaryAccountType,omitempty" snapValidator:"max_length:1"`
+	Currency                 string                 `json:"currency,omitempty" snapValidator:"max_length:3"`
+	AdditionalInfo           map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (InternalInquiryResponse) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.InternalAccountInquiry
+}
+
+// ExternalInquiryRequest is the body of
+// POST /v1.0/account-inquiry-external.
+type ExternalInquiryRequest struct {
+	BeneficiaryBankCode  string                 `json:"beneficiaryBankCode" snapValidator:"required|max_length:8"`
+	BeneficiaryAccountNo string                 `json:"beneficiaryAccountNo" snapValidator:"required|max_length:34" snapMask:"true"`
+	PartnerReferenceNo   string                 `json:"partnerReferenceNo,omitempty" snapValidator:"max_length:64"`
+	AdditionalInfo       map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (ExternalInquiryRequest) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.ExternalAccountInquiry
+}
+
+// ExternalInquiryResponse is the response of
+// POST /v1.0/account-inquiry-external.
+type ExternalInquiryResponse struct {
+	ResponseCode           string                 `json:"responseCode" snapValidator:"required|min_length:7|max_length:7|numeric"`
+	ResponseMessage        string                 `json:"responseMessage" snapValidator:"required|max_length:150"`
+	ReferenceNo            string                 `json:"referenceNo,omitempty" snapValidator:"max_length:64"`
+	PartnerReferenceNo     string                 `json:"partnerReferenceNo,omitempty" snapValidator:"max_length:64"`
+	BeneficiaryAccountName string                 `json:"beneficiaryAccountName,omitempty" snapValidator:"max_length:100"`
+	BeneficiaryAccountNo   string                 `json:"beneficiaryAccountNo,omitempty" snapValidator:"max_length:34" snapMask:"true"`
+	BeneficiaryBankCode    string                 `json:"beneficiaryBankCode,omitempty" snapValidator:"max_length:8"`
+	BeneficiaryBankName    string                 `json:"beneficiaryBankName,omitempty" snapValidator:"max_length:50"`
+	Currency               string                 `json:"currency,omitempty" snapValidator:"max_length:3"`
+	AdditionalInfo         map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (ExternalInquiryResponse) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.ExternalAccountInquiry
+}
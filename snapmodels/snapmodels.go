@@ -0,0 +1,23 @@
+// Package snapmodels holds the types shared by the SNAP request and response
+// models in its sub packages, e.g. snapmodels/va.
+package snapmodels
+
+import "github.com/apelweb15/snap-validator/snap_validator_services"
+
+// Model is a SNAP request or response tied to its service code, so it can be
+// validated without passing the code.
+type Model interface {
+	ServiceCode() snap_validator_services.ServiceCode
+}
+
+// Amount is a SNAP amount object, e.g. {"value":"10000.00","currency":"IDR"}.
+type Amount struct {
+	Value    string `json:"value" snapValidator:"required|max_length:16|amount"`
+	Currency string `json:"currency" snapValidator:"required|min_length:3|max_length:3|alpha_numeric"`
+}
+
+// MultiLanguage is a text in Indonesian and English.
+type MultiLanguage struct {
+	English   string `json:"english,omitempty" snapValidator:"max_length:64"`
+	Indonesia string `json:"indonesia,omitempty" snapValidator:"max_length:64"`
+}
@@ -0,0 +1,78 @@
+package transfer
+
+import (
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"github.com/apelweb15/snap-validator/snapmodels"
+)
+
+// ClearingTransfer is a transfer through RTGS or SKN (services 22 and 23).
+// Residence is 1 for residents and 2 for non residents; customer type is 1
+// for individuals, 2 for corporations and 3 for governments.
+type ClearingTransfer struct {
+	PartnerReferenceNo           string                 `json:"partnerReferenceNo" snapValidator:"required|max_length:64"`
+	Amount                       snapmodels.Amount      `json:"amount" snapValidator:"required"`
+	BeneficiaryAccountName       string                 `json:"beneficiaryAccountName" snapValidator:"required|max_length:100"`
+	BeneficiaryAccountNo         string                 `json:"beneficiaryAccountNo" snapValidator:"required|max_length:34" snapMask:"true"`
+	BeneficiaryAccountAddress    string                 `json:"beneficiaryAccountAddress,omitempty" snapValidator:"max_length:100"`
+	BeneficiaryBankCode          string                 `json:"beneficiaryBankCode" snapValidator:"required|max_length:8"`
+	BeneficiaryBankName          string                 `json:"beneficiaryBankName,omitempty" snapValidator:"max_length:50"`
+	BeneficiaryCustomerResidence string                 `json:"beneficiaryCustomerResidence" snapValidator:"required|in_data:[1,2]"`
+	BeneficiaryCustomerType      string                 `json:"beneficiaryCustomerType" snapValidator:"required|in_data:[1,2,3]"`
+	BeneficiaryEmail             string                 `json:"beneficiaryEmail,omitempty" snapValidator:"max_length:50|email"`
+	Currency                     string                 `json:"currency,omitempty" snapValidator:"max_length:3"`
+	CustomerReference            string                 `json:"customerReference,omitempty" snapValidator:"max_length:64"`
+	FeeType                      string                 `json:"feeType,omitempty" snapValidator:"in_data:[OUR,BEN,SHA]"`
+	Kodepos                      string                 `json:"kodepos,omitempty" snapValidator:"max_length:5|numeric"`
+	ReceiverPhone                string                 `json:"receiverPhone,omitempty" snapValidator:"max_length:15|numeric"`
+	Remark                       string                 `json:"remark,omitempty" snapValidator:"max_length:50"`
+	SenderCustomerResidence      string                 `json:"senderCustomerResidence,omitempty" snapValidator:"in_data:[1,2]"`
+	SenderCustomerType           string                 `json:"senderCustomerType,omitempty" snapValidator:"in_data:[1,2,3]"`
+	SenderPhone                  string                 `json:"senderPhone,omitempty" snapValidator:"max_length:15|numeric"`
+	SourceAccountNo              string                 `json:"sourceAccountNo" snapValidator:"required|max_length:19" snapMask:"true"`
+	TransactionDate              string                 `json:"transactionDate" snapValidator:"required|max_length:25|iso_date"`
+	OriginatorInfos              []OriginatorInfo       `json:"originatorInfos,omitempty"`
+	AdditionalInfo               map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+// ClearingResponse is the response of an RTGS or SKN transfer.
+type ClearingResponse struct {
+	ResponseCode         string                 `json:"responseCode" snapValidator:"required|min_length:7|max_length:7|numeric"`
+	ResponseMessage      string                 `json:"responseMessage" snapValidator:"required|max_length:150"`
+	ReferenceNo          string                 `json:"referenceNo,omitempty" snapValidator:"max_length:64"`
+	PartnerReferenceNo   string                 `json:"partnerReferenceNo,omitempty" snapValidator:"max_length:64"`
+	Amount               snapmodels.Amount      `json:"amount,omitempty"`
+	BeneficiaryAccountNo string                 `json:"beneficiaryAccountNo,omitempty" snapValidator:"max_length:34" snapMask:"true"`
+	BeneficiaryBankCode  string                 `json:"beneficiaryBankCode,omitempty" snapValidator:"max_length:8"`
+	SourceAccountNo      string                 `json:"sourceAccountNo,omitempty" snapValidator:"max_length:19" snapMask:"true"`
+	TraceNo              string                 `json:"traceNo,omitempty" snapValidator:"max_length:64"`
+	TransactionDate      string                 `json:"transactionDate,omitempty" snapValidator:"max_length:25|iso_date"`
+	AdditionalInfo       map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+// RtgsRequest is the body of POST /v1.0/transfer-rtgs.
+type RtgsRequest ClearingTransfer
+
+func (RtgsRequest) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.RtgsTransfer
+}
+
+// RtgsResponse is the response of POST /v1.0/transfer-rtgs.
+type RtgsResponse ClearingResponse
+
+func (RtgsResponse) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.RtgsTransfer
+}
+
+// SknRequest is the body of POST /v1.0/transfer-skn.
+type SknRequest ClearingTransfer
+
+func (SknRequest) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.SknTransfer
+}
+
+// SknResponse is the response of POST /v1.0/transfer-skn.
+type SknResponse ClearingResponse
+
+func (SknResponse) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.SknTransfer
+}
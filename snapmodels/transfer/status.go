@@ -0,0 +1,61 @@
+package transfer
+
+import (
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"github.com/apelweb15/snap-validator/snapmodels"
+)
+
+// Latest transaction statuses of a status inquiry.
+const (
+	StatusSuccess   = "00"
+	StatusInitiated = "01"
+	StatusPaying    = "02"
+	StatusPending   = "03"
+	StatusRefunded  = "04"
+	StatusCanceled  = "05"
+	StatusFailed    = "06"
+	StatusNotFound  = "07"
+)
+
+// StatusInquiryRequest is the body of POST /v1.0/transfer/status. The
+// original transaction is identified by originalReferenceNo or
+// originalPartnerReferenceNo. OriginalServiceCode, sent as serviceCode, is
+// the service of the original transaction, e.g. 17.
+type StatusInquiryRequest struct {
+	OriginalPartnerReferenceNo string                 `json:"originalPartnerReferenceNo,omitempty" snapValidator:"required_without:originalReferenceNo|max_length:64"`
+	OriginalReferenceNo        string                 `json:"originalReferenceNo,omitempty" snapValidator:"max_length:64"`
+	OriginalExternalId         string                 `json:"originalExternalId,omitempty" snapValidator:"max_length:36|numeric"`
+	OriginalServiceCode        string                 `json:"serviceCode" snapValidator:"required|min_length:2|max_length:2|numeric"`
+	TransactionDate            string                 `json:"transactionDate,omitempty" snapValidator:"max_length:25|iso_date"`
+	AdditionalInfo             map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (StatusInquiryRequest) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.TransferStatusInquiry
+}
+
+// StatusInquiryResponse is the response of POST /v1.0/transfer/status.
+type StatusInquiryResponse struct {
+	ResponseCode               string                 `json:"responseCode" snapValidator:"required|min_length:7|max_length:7|numeric"`
+	ResponseMessage            string                 `json:"responseMessage" snapValidator:"required|max_length:150"`
+	OriginalReferenceNo        string                 `json:"originalReferenceNo,omitempty" snapValidator:"max_length:64"`
+	OriginalPartnerReferenceNo string                 `json:"originalPartnerReferenceNo,omitempty" snapValidator:"max_length:64"`
+	OriginalExternalId         string                 `json:"originalExternalId,omitempty" snapValidator:"max_length:36"`
+	OriginalServiceCode        string                 `json:"serviceCode" snapValidator:"required|min_length:2|max_length:2|numeric"`
+	TransactionDate            string                 `json:"transactionDate,omitempty" snapValidator:"max_length:25|iso_date"`
+	Amount                     snapmodels.Amount      `json:"amount,omitempty"`
+	BeneficiaryAccountNo       string                 `json:"beneficiaryAccountNo,omitempty" snapValidator:"max_length:34" snapMask:"true"`
+	BeneficiaryBankCode        string                 `json:"beneficiaryBankCode,omitempty" snapValidator:"max_length:8"`
+	Currency                   string                 `json:"currency,omitempty" snapValidator:"max_length:3"`
+	PreviousResponseCode       string                 `json:"previousResponseCode,omitempty" snapValidator:"max_length:7|numeric"`
+	ReferenceNumber            string                 `json:"referenceNumber,omitempty" snapValidator:"max_length:64"`
+	SourceAccountNo            string                 `json:"sourceAccountNo,omitempty" snapValidator:"max_length:19" snapMask:"true"`
+	TransactionId              string                 `json:"transactionId,omitempty" snapValidator:"max_length:64"`
+	LatestTransactionStatus    string                 `json:"latestTransactionStatus" snapValidator:"required|in_data:[00,01,02,03,04,05,06,07]"`
+	TransactionStatusDesc      string                 `json:"transactionStatusDesc,omitempty" snapValidator:"max_length:50"`
+	AdditionalInfo             map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (StatusInquiryResponse) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.TransferStatusInquiry
+}
@@ -0,0 +1,107 @@
+// Package transfer holds the SNAP fund transfer request and response models
+// with their validation rules, following the BI SNAP specification. Account
+// numbers are masked in ErrorValidation.Value.
+//
+//	err := snap_validator.ValidateModel(req)
+package transfer
+
+import (
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"github.com/apelweb15/snap-validator/snapmodels"
+)
+
+// Fee types: paid by the sender, the beneficiary or shared.
+const (
+	FeeTypeOur = "OUR"
+	FeeTypeBen = "BEN"
+	FeeTypeSha = "SHA"
+)
+
+// OriginatorInfo identifies the originator of a transfer.
+type OriginatorInfo struct {
+	OriginatorCustomerNo   string `json:"originatorCustomerNo,omitempty" snapValidator:"max_length:64"`
+	OriginatorCustomerName string `json:"originatorCustomerName,omitempty" snapValidator:"max_length:140"`
+	OriginatorBankCode     string `json:"originatorBankCode,omitempty" snapValidator:"max_length:8"`
+}
+
+// IntrabankRequest is the body of POST /v1.0/transfer-intrabank.
+type IntrabankRequest struct {
+	PartnerReferenceNo   string                 `json:"partnerReferenceNo" snapValidator:"required|max_length:64"`
+	Amount               snapmodels.Amount      `json:"amount" snapValidator:"required"`
+	BeneficiaryAccountNo string                 `json:"beneficiaryAccountNo" snapValidator:"required|max_length:34" snapMask:"true"`
+	BeneficiaryEmail     string                 `json:"beneficiaryEmail,omitempty" snapValidator:"max_length:50|email"`
+	Currency             string                 `json:"currency,omitempty" snapValidator:"max_length:3"`
+	CustomerReference    string                 `json:"customerReference,omitempty" snapValidator:"max_length:64"`
+	FeeType              string                 `json:"feeType,omitempty" snapValidator:"in_data:[OUR,BEN,SHA]"`
+	Remark               string                 `json:"remark,omitempty" snapValidator:"max_length:50"`
+	SourceAccountNo      string                 `json:"sourceAccountNo" snapValidator:"required|max_length:19" snapMask:"true"`
+	TransactionDate      string                 `json:"transactionDate" snapValidator:"required|max_length:25|iso_date"`
+	OriginatorInfos      []OriginatorInfo       `json:"originatorInfos,omitempty"`
+	AdditionalInfo       map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (IntrabankRequest) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.IntrabankTransfer
+}
+
+// IntrabankResponse is the response of POST /v1.0/transfer-intrabank.
+type IntrabankResponse struct {
+	ResponseCode         string                 `json:"responseCode" snapValidator:"required|min_length:7|max_length:7|numeric"`
+	ResponseMessage      string                 `json:"responseMessage" snapValidator:"required|max_length:150"`
+	ReferenceNo          string                 `json:"referenceNo,omitempty" snapValidator:"max_length:64"`
+	PartnerReferenceNo   string                 `json:"partnerReferenceNo,omitempty" snapValidator:"max_length:64"`
+	Amount               snapmodels.Amount      `json:"amount,omitempty"`
+	BeneficiaryAccountNo string                 `json:"beneficiaryAccountNo,omitempty" snapValidator:"max_length:34" snapMask:"true"`
+	Currency             string                 `json:"currency,omitempty" snapValidator:"max_length:3"`
+	CustomerReference    string                 `json:"customerReference,omitempty" snapValidator:"max_length:64"`
+	SourceAccountNo      string                 `json:"sourceAccountNo,omitempty" snapValidator:"max_length:19" snapMask:"true"`
+	TransactionDate      string                 `json:"transactionDate,omitempty" snapValidator:"max_length:25|iso_date"`
+	OriginatorInfos      []OriginatorInfo       `json:"originatorInfos,omitempty"`
+	AdditionalInfo       map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (IntrabankResponse) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.IntrabankTransfer
+}
+
+// InterbankRequest is the body of POST /v1.0/transfer-interbank.
+type InterbankRequest struct {
+	PartnerReferenceNo     string                 `json:"partnerReferenceNo" snapValidator:"required|max_length:64"`
+	Amount                 snapmodels.Amount      `json:"amount" snapValidator:"required"`
+	BeneficiaryAccountName string                 `json:"beneficiaryAccountName" snapValidator:"required|max_length:100"`
+	BeneficiaryAccountNo   string                 `json:"beneficiaryAccountNo" snapValidator:"required|max_length:34" snapMask:"true"`
+	BeneficiaryAddress     string                 `json:"beneficiaryAddress,omitempty" snapValidator:"max_length:100"`
+	BeneficiaryBankCode    string                 `json:"beneficiaryBankCode" snapValidator:"required|max_length:8"`
+	BeneficiaryBankName    string                 `json:"beneficiaryBankName,omitempty" snapValidator:"max_length:50"`
+	BeneficiaryEmail       string                 `json:"beneficiaryEmail,omitempty" snapValidator:"max_length:50|email"`
+	Currency               string                 `json:"currency,omitempty" snapValidator:"max_length:3"`
+	CustomerReference      string                 `json:"customerReference,omitempty" snapValidator:"max_length:64"`
+	FeeType                string                 `json:"feeType,omitempty" snapValidator:"in_data:[OUR,BEN,SHA]"`
+	SourceAccountNo        string                 `json:"sourceAccountNo" snapValidator:"required|max_length:19" snapMask:"true"`
+	TransactionDate        string                 `json:"transactionDate" snapValidator:"required|max_length:25|iso_date"`
+	OriginatorInfos        []OriginatorInfo       `json:"originatorInfos,omitempty"`
+	AdditionalInfo         map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (InterbankRequest) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.InterbankTransfer
+}
+
+// InterbankResponse is the response of POST /v1.0/transfer-interbank.
+type InterbankResponse struct {
+	ResponseCode         string                 `json:"responseCode" snapValidator:"required|min_length:7|max_length:7|numeric"`
+	ResponseMessage      string                 `json:"responseMessage" snapValidator:"required|max_length:150"`
+	ReferenceNo          string                 `json:"referenceNo,omitempty" snapValidator:"max_length:64"`
+	PartnerReferenceNo   string                 `json:"partnerReferenceNo,omitempty" snapValidator:"max_length:64"`
+	Amount               snapmodels.Amount      `json:"amount,omitempty"`
+	BeneficiaryAccountNo string                 `json:"beneficiaryAccountNo,omitempty" snapValidator:"max_length:34" snapMask:"true"`
+	BeneficiaryBankCode  string                 `json:"beneficiaryBankCode,omitempty" snapValidator:"max_length:8"`
+	SourceAccountNo      string                 `json:"sourceAccountNo,omitempty" snapValidator:"max_length:19" snapMask:"true"`
+	TransactionDate      string                 `json:"transactionDate,omitempty" snapValidator:"max_length:25|iso_date"`
+	OriginatorInfos      []OriginatorInfo       `json:"originatorInfos,omitempty"`
+	AdditionalInfo       map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (InterbankResponse) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.InterbankTransfer
+}
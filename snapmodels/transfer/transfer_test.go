@@ -0,0 +1,66 @@
+package transfer
+
+import (
+	"errors"
+	snap_validator "github.com/apelweb15/snap-validator"
+	"github.com/apelweb15/snap-validator/snap_validator_errors"
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"github.com/apelweb15/snap-validator/snapmodels"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestModelsCompile(t *testing.T) {
+	models := []snapmodels.Model{
+		IntrabankRequest{}, IntrabankResponse{},
+		InterbankRequest{}, InterbankResponse{},
+		RtgsRequest{}, RtgsResponse{},
+		SknRequest{}, SknResponse{},
+		StatusInquiryRequest{}, StatusInquiryResponse{},
+	}
+	for _, model := range models {
+		assert.NoError(t, snap_validator.Compile(model))
+	}
+	assert.Equal(t, snap_validator_services.SknTransfer, SknRequest{}.ServiceCode())
+}
+
+func TestValidateModel(t *testing.T) {
+	req := IntrabankRequest{
+		PartnerReferenceNo:   "2020102900000000000001",
+		Amount:               snapmodels.Amount{Value: "12345678.00", Currency: "IDR"},
+		BeneficiaryAccountNo: "888801000157508",
+		FeeType:              FeeTypeOur,
+		SourceAccountNo:      "888801000157508",
+		TransactionDate:      "2024-06-01T10:00:00+07:00",
+	}
+	assert.NoError(t, snap_validator.ValidateModel(req))
+
+	var errorRes *snap_validator_errors.ErrorValidation
+	req.FeeType = "ALL"
+	assert.True(t, errors.As(snap_validator.ValidateModel(req), &errorRes))
+	assert.Equal(t, "4001701", errorRes.SnapCode)
+	assert.Equal(t, "feeType", errorRes.Path)
+
+	rtgs := RtgsRequest{
+		PartnerReferenceNo:           "2020102900000000000001",
+		Amount:                       snapmodels.Amount{Value: "150000000.00", Currency: "IDR"},
+		BeneficiaryAccountName:       "Yories Yolanda",
+		BeneficiaryAccountNo:         "888801000157508",
+		BeneficiaryBankCode:          "002",
+		BeneficiaryCustomerResidence: "1",
+		SourceAccountNo:              "888801000157508",
+		TransactionDate:              "2024-06-01T10:00:00+07:00",
+	}
+	assert.True(t, errors.As(snap_validator.ValidateModel(rtgs), &errorRes))
+	assert.Equal(t, "4002202", errorRes.SnapCode)
+	assert.Equal(t, "beneficiaryCustomerType", errorRes.Path)
+
+	rtgs.BeneficiaryCustomerType = "1"
+	assert.NoError(t, snap_validator.ValidateModel(rtgs))
+	assert.NoError(t, snap_validator.ValidateModel(SknRequest(rtgs)))
+
+	status := StatusInquiryRequest{OriginalServiceCode: "17"}
+	assert.True(t, errors.As(snap_validator.ValidateModel(status), &errorRes))
+	assert.Equal(t, "4003602", errorRes.SnapCode)
+	assert.Equal(t, "originalPartnerReferenceNo", errorRes.Path)
+}
@@ -0,0 +1,57 @@
+package va
+
+import (
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"github.com/apelweb15/snap-validator/snapmodels"
+)
+
+// InquiryRequest is the body of POST /v1.0/transfer-va/inquiry.
+type InquiryRequest struct {
+	PartnerServiceId      string                 `json:"partnerServiceId" snapValidator:"required|min_length:8|max_length:8"`
+	CustomerNo            string                 `json:"customerNo" snapValidator:"required|max_length:20|numeric"`
+	VirtualAccountNo      string                 `json:"virtualAccountNo" snapValidator:"required|max_length:28"`
+	TrxDateInit           string                 `json:"trxDateInit,omitempty" snapValidator:"max_length:25|iso_date"`
+	ChannelCode           string                 `json:"channelCode,omitempty" snapValidator:"max_length:4|numeric"`
+	Language              string                 `json:"language,omitempty" snapValidator:"max_length:2"`
+	Amount                snapmodels.Amount      `json:"amount"`
+	HashedSourceAccountNo string                 `json:"hashedSourceAccountNo,omitempty" snapValidator:"max_length:32"`
+	SourceBankCode        string                 `json:"sourceBankCode,omitempty" snapValidator:"max_length:3|numeric"`
+	PassApp               string                 `json:"passApp,omitempty" snapValidator:"max_length:64"`
+	InquiryRequestId      string                 `json:"inquiryRequestId" snapValidator:"required|max_length:128"`
+	AdditionalInfo        map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (InquiryRequest) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.VirtualAccountInquiry
+}
+
+// InquiryData is the virtual account returned by an inquiry.
+type InquiryData struct {
+	InquiryStatus         string                     `json:"inquiryStatus,omitempty" snapValidator:"max_length:2|in_data:[00,01]"`
+	InquiryReason         snapmodels.MultiLanguage   `json:"inquiryReason,omitempty"`
+	PartnerServiceId      string                     `json:"partnerServiceId" snapValidator:"required|min_length:8|max_length:8"`
+	CustomerNo            string                     `json:"customerNo" snapValidator:"required|max_length:20|numeric"`
+	VirtualAccountNo      string                     `json:"virtualAccountNo" snapValidator:"required|max_length:28"`
+	VirtualAccountName    string                     `json:"virtualAccountName" snapValidator:"required|max_length:255"`
+	VirtualAccountEmail   string                     `json:"virtualAccountEmail,omitempty" snapValidator:"max_length:255|email"`
+	VirtualAccountPhone   string                     `json:"virtualAccountPhone,omitempty" snapValidator:"max_length:30|numeric"`
+	InquiryRequestId      string                     `json:"inquiryRequestId" snapValidator:"required|max_length:128"`
+	TotalAmount           snapmodels.Amount          `json:"totalAmount" snapValidator:"required_if:virtualAccountTrxType,C,I"`
+	SubCompany            string                     `json:"subCompany,omitempty" snapValidator:"max_length:5|numeric"`
+	BillDetails           []BillDetail               `json:"billDetails,omitempty" snapValidator:"max_items:24"`
+	FreeTexts             []snapmodels.MultiLanguage `json:"freeTexts,omitempty" snapValidator:"max_items:25"`
+	VirtualAccountTrxType string                     `json:"virtualAccountTrxType,omitempty" snapValidator:"max_length:1|in_data:[C,O,I,M,L,N,X,V,W]"`
+	FeeAmount             snapmodels.Amount          `json:"feeAmount"`
+	AdditionalInfo        map[string]interface{}     `json:"additionalInfo,omitempty"`
+}
+
+// InquiryResponse is the response of POST /v1.0/transfer-va/inquiry.
+type InquiryResponse struct {
+	ResponseCode       string       `json:"responseCode" snapValidator:"required|min_length:7|max_length:7|numeric"`
+	ResponseMessage    string       `json:"responseMessage" snapValidator:"required|max_length:150"`
+	VirtualAccountData *InquiryData `json:"virtualAccountData,omitempty"`
+}
+
+func (InquiryResponse) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.VirtualAccountInquiry
+}
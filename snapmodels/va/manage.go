@@ -0,0 +1,96 @@
+package va
+
+import "github.com/apelweb15/snap-validator/snap_validator_services"
+
+// CreateRequest is the body of POST /v1.0/transfer-va/create-va.
+type CreateRequest VirtualAccount
+
+func (CreateRequest) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.VirtualAccountCreate
+}
+
+// CreateResponse is the response of POST /v1.0/transfer-va/create-va.
+type CreateResponse struct {
+	ResponseCode       string          `json:"responseCode" snapValidator:"required|min_length:7|max_length:7|numeric"`
+	ResponseMessage    string          `json:"responseMessage" snapValidator:"required|max_length:150"`
+	VirtualAccountData *VirtualAccount `json:"virtualAccountData,omitempty"`
+}
+
+func (CreateResponse) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.VirtualAccountCreate
+}
+
+// UpdateRequest is the body of PUT /v1.0/transfer-va/update-va.
+type UpdateRequest VirtualAccount
+
+func (UpdateRequest) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.VirtualAccountUpdate
+}
+
+// UpdateResponse is the response of PUT /v1.0/transfer-va/update-va.
+type UpdateResponse struct {
+	ResponseCode       string          `json:"responseCode" snapValidator:"required|min_length:7|max_length:7|numeric"`
+	ResponseMessage    string          `json:"responseMessage" snapValidator:"required|max_length:150"`
+	VirtualAccountData *VirtualAccount `json:"virtualAccountData,omitempty"`
+}
+
+func (UpdateResponse) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.VirtualAccountUpdate
+}
+
+// InquiryVARequest is the body of POST /v1.0/transfer-va/inquiry-va.
+type InquiryVARequest struct {
+	PartnerServiceId string                 `json:"partnerServiceId" snapValidator:"required|min_length:8|max_length:8"`
+	CustomerNo       string                 `json:"customerNo" snapValidator:"required|max_length:20|numeric"`
+	VirtualAccountNo string                 `json:"virtualAccountNo" snapValidator:"required|max_length:28"`
+	TrxId            string                 `json:"trxId,omitempty" snapValidator:"max_length:64|alpha_numeric_symbol"`
+	AdditionalInfo   map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (InquiryVARequest) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.VirtualAccountInquiryVA
+}
+
+// InquiryVAResponse is the response of POST /v1.0/transfer-va/inquiry-va.
+type InquiryVAResponse struct {
+	ResponseCode       string          `json:"responseCode" snapValidator:"required|min_length:7|max_length:7|numeric"`
+	ResponseMessage    string          `json:"responseMessage" snapValidator:"required|max_length:150"`
+	VirtualAccountData *VirtualAccount `json:"virtualAccountData,omitempty"`
+}
+
+func (InquiryVAResponse) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.VirtualAccountInquiryVA
+}
+
+// DeleteRequest is the body of DELETE /v1.0/transfer-va/delete-va.
+type DeleteRequest struct {
+	PartnerServiceId string                 `json:"partnerServiceId" snapValidator:"required|min_length:8|max_length:8"`
+	CustomerNo       string                 `json:"customerNo" snapValidator:"required|max_length:20|numeric"`
+	VirtualAccountNo string                 `json:"virtualAccountNo" snapValidator:"required|max_length:28"`
+	TrxId            string                 `json:"trxId,omitempty" snapValidator:"max_length:64|alpha_numeric_symbol"`
+	AdditionalInfo   map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (DeleteRequest) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.VirtualAccountDelete
+}
+
+// DeleteData is the virtual account removed by a delete.
+type DeleteData struct {
+	PartnerServiceId string                 `json:"partnerServiceId" snapValidator:"required|min_length:8|max_length:8"`
+	CustomerNo       string                 `json:"customerNo" snapValidator:"required|max_length:20|numeric"`
+	VirtualAccountNo string                 `json:"virtualAccountNo" snapValidator:"required|max_length:28"`
+	TrxId            string                 `json:"trxId,omitempty" snapValidator:"max_length:64|alpha_numeric_symbol"`
+	AdditionalInfo   map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+// DeleteResponse is the response of DELETE /v1.0/transfer-va/delete-va.
+type DeleteResponse struct {
+	ResponseCode       string      `json:"responseCode" snapValidator:"required|min_length:7|max_length:7|numeric"`
+	ResponseMessage    string      `json:"responseMessage" snapValidator:"required|max_length:150"`
+	VirtualAccountData *DeleteData `json:"virtualAccountData,omitempty"`
+}
+
+func (DeleteResponse) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.VirtualAccountDelete
+}
@@ -0,0 +1,77 @@
+package va
+
+import (
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"github.com/apelweb15/snap-validator/snapmodels"
+)
+
+// PaymentRequest is the body of POST /v1.0/transfer-va/payment.
+type PaymentRequest struct {
+	PartnerServiceId        string                     `json:"partnerServiceId" snapValidator:"required|min_length:8|max_length:8"`
+	CustomerNo              string                     `json:"customerNo" snapValidator:"required|max_length:20|numeric"`
+	VirtualAccountNo        string                     `json:"virtualAccountNo" snapValidator:"required|max_length:28"`
+	VirtualAccountName      string                     `json:"virtualAccountName,omitempty" snapValidator:"max_length:255"`
+	VirtualAccountEmail     string                     `json:"virtualAccountEmail,omitempty" snapValidator:"max_length:255|email"`
+	VirtualAccountPhone     string                     `json:"virtualAccountPhone,omitempty" snapValidator:"max_length:30|numeric"`
+	TrxId                   string                     `json:"trxId,omitempty" snapValidator:"max_length:64|alpha_numeric_symbol"`
+	PaymentRequestId        string                     `json:"paymentRequestId" snapValidator:"required|max_length:128"`
+	ChannelCode             string                     `json:"channelCode,omitempty" snapValidator:"max_length:4|numeric"`
+	HashedSourceAccountNo   string                     `json:"hashedSourceAccountNo,omitempty" snapValidator:"max_length:32"`
+	SourceBankCode          string                     `json:"sourceBankCode,omitempty" snapValidator:"max_length:3|numeric"`
+	PaidAmount              snapmodels.Amount          `json:"paidAmount" snapValidator:"required"`
+	CumulativePaymentAmount snapmodels.Amount          `json:"cumulativePaymentAmount"`
+	PaidBills               string                     `json:"paidBills,omitempty" snapValidator:"max_length:6"`
+	TotalAmount             snapmodels.Amount          `json:"totalAmount"`
+	TrxDateTime             string                     `json:"trxDateTime,omitempty" snapValidator:"max_length:25|iso_date"`
+	ReferenceNo             string                     `json:"referenceNo,omitempty" snapValidator:"max_length:64"`
+	JournalNum              string                     `json:"journalNum,omitempty" snapValidator:"max_length:6|numeric"`
+	PaymentType             string                     `json:"paymentType,omitempty" snapValidator:"max_length:1"`
+	FlagAdvise              string                     `json:"flagAdvise,omitempty" snapValidator:"max_length:1|in_data:[Y,N]"`
+	SubCompany              string                     `json:"subCompany,omitempty" snapValidator:"max_length:5|numeric"`
+	BillDetails             []PaidBillDetail           `json:"billDetails,omitempty" snapValidator:"max_items:24"`
+	FreeTexts               []snapmodels.MultiLanguage `json:"freeTexts,omitempty" snapValidator:"max_items:25"`
+	AdditionalInfo          map[string]interface{}     `json:"additionalInfo,omitempty"`
+}
+
+func (PaymentRequest) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.VirtualAccountPayment
+}
+
+// PaymentData is the virtual account payment returned by a payment or a
+// payment status inquiry.
+type PaymentData struct {
+	PaymentFlagReason   snapmodels.MultiLanguage   `json:"paymentFlagReason,omitempty"`
+	PartnerServiceId    string                     `json:"partnerServiceId" snapValidator:"required|min_length:8|max_length:8"`
+	CustomerNo          string                     `json:"customerNo" snapValidator:"required|max_length:20|numeric"`
+	VirtualAccountNo    string                     `json:"virtualAccountNo" snapValidator:"required|max_length:28"`
+	VirtualAccountName  string                     `json:"virtualAccountName,omitempty" snapValidator:"max_length:255"`
+	VirtualAccountEmail string                     `json:"virtualAccountEmail,omitempty" snapValidator:"max_length:255|email"`
+	VirtualAccountPhone string                     `json:"virtualAccountPhone,omitempty" snapValidator:"max_length:30|numeric"`
+	TrxId               string                     `json:"trxId,omitempty" snapValidator:"max_length:64|alpha_numeric_symbol"`
+	InquiryRequestId    string                     `json:"inquiryRequestId,omitempty" snapValidator:"max_length:128"`
+	PaymentRequestId    string                     `json:"paymentRequestId" snapValidator:"required|max_length:128"`
+	PaidAmount          snapmodels.Amount          `json:"paidAmount"`
+	PaidBills           string                     `json:"paidBills,omitempty" snapValidator:"max_length:6"`
+	TotalAmount         snapmodels.Amount          `json:"totalAmount"`
+	TrxDateTime         string                     `json:"trxDateTime,omitempty" snapValidator:"max_length:25|iso_date"`
+	TransactionDate     string                     `json:"transactionDate,omitempty" snapValidator:"max_length:25|iso_date"`
+	ReferenceNo         string                     `json:"referenceNo,omitempty" snapValidator:"max_length:64"`
+	JournalNum          string                     `json:"journalNum,omitempty" snapValidator:"max_length:6|numeric"`
+	PaymentType         string                     `json:"paymentType,omitempty" snapValidator:"max_length:1"`
+	FlagAdvise          string                     `json:"flagAdvise,omitempty" snapValidator:"max_length:1|in_data:[Y,N]"`
+	PaymentFlagStatus   string                     `json:"paymentFlagStatus,omitempty" snapValidator:"max_length:2|in_data:[00,01,02]"`
+	BillDetails         []PaidBillDetail           `json:"billDetails,omitempty" snapValidator:"max_items:24"`
+	FreeTexts           []snapmodels.MultiLanguage `json:"freeTexts,omitempty" snapValidator:"max_items:25"`
+	AdditionalInfo      map[string]interface{}     `json:"additionalInfo,omitempty"`
+}
+
+// PaymentResponse is the response of POST /v1.0/transfer-va/payment.
+type PaymentResponse struct {
+	ResponseCode       string       `json:"responseCode" snapValidator:"required|min_length:7|max_length:7|numeric"`
+	ResponseMessage    string       `json:"responseMessage" snapValidator:"required|max_length:150"`
+	VirtualAccountData *PaymentData `json:"virtualAccountData,omitempty"`
+}
+
+func (PaymentResponse) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.VirtualAccountPayment
+}
@@ -0,0 +1,29 @@
+package va
+
+import "github.com/apelweb15/snap-validator/snap_validator_services"
+
+// StatusRequest is the body of POST /v1.0/transfer-va/status. One of
+// inquiryRequestId and paymentRequestId identifies the transaction.
+type StatusRequest struct {
+	PartnerServiceId string                 `json:"partnerServiceId" snapValidator:"required|min_length:8|max_length:8"`
+	CustomerNo       string                 `json:"customerNo" snapValidator:"required|max_length:20|numeric"`
+	VirtualAccountNo string                 `json:"virtualAccountNo" snapValidator:"required|max_length:28"`
+	InquiryRequestId string                 `json:"inquiryRequestId,omitempty" snapValidator:"required_without:paymentRequestId|max_length:128"`
+	PaymentRequestId string                 `json:"paymentRequestId,omitempty" snapValidator:"max_length:128"`
+	AdditionalInfo   map[string]interface{} `json:"additionalInfo,omitempty"`
+}
+
+func (StatusRequest) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.VirtualAccountStatus
+}
+
+// StatusResponse is the response of POST /v1.0/transfer-va/status.
+type StatusResponse struct {
+	ResponseCode       string       `json:"responseCode" snapValidator:"required|min_length:7|max_length:7|numeric"`
+	ResponseMessage    string       `json:"responseMessage" snapValidator:"required|max_length:150"`
+	VirtualAccountData *PaymentData `json:"virtualAccountData,omitempty"`
+}
+
+func (StatusResponse) ServiceCode() snap_validator_services.ServiceCode {
+	return snap_validator_services.VirtualAccountStatus
+}
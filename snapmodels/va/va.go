@@ -0,0 +1,66 @@
+// Package va holds the SNAP Virtual Account request and response models with
+// their validation rules, following the BI SNAP specification.
+//
+//	err := snap_validator.ValidateModel(req)
+package va
+
+import "github.com/apelweb15/snap-validator/snapmodels"
+
+// Virtual account transaction types.
+const (
+	TrxTypeClosed         = "C"
+	TrxTypeOpen           = "O"
+	TrxTypePartial        = "I"
+	TrxTypeOpenMinimum    = "M"
+	TrxTypeOpenMaximum    = "L"
+	TrxTypeOpenMinMax     = "N"
+	TrxTypePartialMinimum = "X"
+	TrxTypePartialMaximum = "V"
+	TrxTypePartialMinMax  = "W"
+)
+
+// BillDetail is one bill of a virtual account.
+type BillDetail struct {
+	BillCode        string                   `json:"billCode,omitempty" snapValidator:"max_length:2|numeric"`
+	BillNo          string                   `json:"billNo,omitempty" snapValidator:"max_length:18|numeric"`
+	BillName        string                   `json:"billName,omitempty" snapValidator:"max_length:20"`
+	BillShortName   string                   `json:"billShortName,omitempty" snapValidator:"max_length:10"`
+	BillDescription snapmodels.MultiLanguage `json:"billDescription,omitempty"`
+	BillSubCompany  string                   `json:"billSubCompany,omitempty" snapValidator:"max_length:5|numeric"`
+	BillAmount      snapmodels.Amount        `json:"billAmount"`
+	AdditionalInfo  map[string]interface{}   `json:"additionalInfo,omitempty"`
+}
+
+// PaidBillDetail is one bill of a payment with its payment status.
+type PaidBillDetail struct {
+	BillCode        string                   `json:"billCode,omitempty" snapValidator:"max_length:2|numeric"`
+	BillNo          string                   `json:"billNo,omitempty" snapValidator:"max_length:18|numeric"`
+	BillName        string                   `json:"billName,omitempty" snapValidator:"max_length:20"`
+	BillShortName   string                   `json:"billShortName,omitempty" snapValidator:"max_length:10"`
+	BillDescription snapmodels.MultiLanguage `json:"billDescription,omitempty"`
+	BillSubCompany  string                   `json:"billSubCompany,omitempty" snapValidator:"max_length:5|numeric"`
+	BillAmount      snapmodels.Amount        `json:"billAmount"`
+	BillReferenceNo string                   `json:"billReferenceNo,omitempty" snapValidator:"max_length:15|numeric"`
+	Status          string                   `json:"status,omitempty" snapValidator:"max_length:2|in_data:[00,01]"`
+	Reason          snapmodels.MultiLanguage `json:"reason,omitempty"`
+	AdditionalInfo  map[string]interface{}   `json:"additionalInfo,omitempty"`
+}
+
+// VirtualAccount is the data of a virtual account as created, updated or
+// inquired by its owner (services 27, 28 and 30).
+type VirtualAccount struct {
+	PartnerServiceId      string                     `json:"partnerServiceId" snapValidator:"required|min_length:8|max_length:8"`
+	CustomerNo            string                     `json:"customerNo" snapValidator:"required|max_length:20|numeric"`
+	VirtualAccountNo      string                     `json:"virtualAccountNo" snapValidator:"required|max_length:28"`
+	VirtualAccountName    string                     `json:"virtualAccountName" snapValidator:"required|max_length:255"`
+	VirtualAccountEmail   string                     `json:"virtualAccountEmail,omitempty" snapValidator:"max_length:255|email"`
+	VirtualAccountPhone   string                     `json:"virtualAccountPhone,omitempty" snapValidator:"max_length:30|numeric"`
+	TrxId                 string                     `json:"trxId" snapValidator:"required|max_length:64|alpha_numeric_symbol"`
+	TotalAmount           snapmodels.Amount          `json:"totalAmount" snapValidator:"required_if:virtualAccountTrxType,C,I"`
+	BillDetails           []BillDetail               `json:"billDetails,omitempty" snapValidator:"max_items:24"`
+	FreeTexts             []snapmodels.MultiLanguage `json:"freeTexts,omitempty" snapValidator:"max_items:25"`
+	VirtualAccountTrxType string                     `json:"virtualAccountTrxType" snapValidator:"required|max_length:1|in_data:[C,O,I,M,L,N,X,V,W]"`
+	FeeAmount             snapmodels.Amount          `json:"feeAmount"`
+	ExpiredDate           string                     `json:"expiredDate,omitempty" snapValidator:"max_length:25|iso_date"`
+	AdditionalInfo        map[string]interface{}     `json:"additionalInfo,omitempty"`
+}
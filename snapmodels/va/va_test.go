@@ -0,0 +1,100 @@
+package va
+
+import (
+	"errors"
+	snap_validator "github.com/apelweb15/snap-validator"
+	"github.com/apelweb15/snap-validator/snap_validator_errors"
+	"github.com/apelweb15/snap-validator/snap_validator_services"
+	"github.com/apelweb15/snap-validator/snapmodels"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestModelsCompile(t *testing.T) {
+	models := []snapmodels.Model{
+		InquiryRequest{}, InquiryResponse{},
+		PaymentRequest{}, PaymentResponse{},
+		StatusRequest{}, StatusResponse{},
+		CreateRequest{}, CreateResponse{},
+		UpdateRequest{}, UpdateResponse{},
+		InquiryVARequest{}, InquiryVAResponse{},
+		DeleteRequest{}, DeleteResponse{},
+	}
+	for _, model := range models {
+		assert.NoError(t, snap_validator.Compile(model))
+		assert.True(t, model.ServiceCode().Valid())
+	}
+	assert.Equal(t, snap_validator_services.VirtualAccountInquiryVA, InquiryVARequest{}.ServiceCode())
+}
+
+func TestPaymentRequest(t *testing.T) {
+	req := PaymentRequest{
+		PartnerServiceId: "   12345",
+		CustomerNo:       "123456789012345678",
+		VirtualAccountNo: "   12345123456789012345678",
+		PaymentRequestId: "abcdef-123456-abcdef",
+		PaidAmount:       snapmodels.Amount{Value: "10000.00", Currency: "IDR"},
+		TrxDateTime:      "2024-06-01T10:00:00+07:00",
+		FlagAdvise:       "N",
+		BillDetails: []PaidBillDetail{{
+			BillCode:   "01",
+			BillAmount: snapmodels.Amount{Value: "10000.00", Currency: "IDR"},
+		}},
+	}
+	assert.NoError(t, snap_validator.ValidateModel(req))
+
+	var errorRes *snap_validator_errors.ErrorValidation
+	req.PaidAmount = snapmodels.Amount{}
+	assert.True(t, errors.As(snap_validator.ValidateModel(req), &errorRes))
+	assert.Equal(t, "4002502", errorRes.SnapCode)
+	assert.Equal(t, "paidAmount", errorRes.Path)
+
+	req.PaidAmount = snapmodels.Amount{Value: "10000", Currency: "IDR"}
+	assert.True(t, errors.As(snap_validator.ValidateModel(req), &errorRes))
+	assert.Equal(t, "4002501", errorRes.SnapCode)
+	assert.Equal(t, "paidAmount.value", errorRes.Path)
+}
+
+func TestStatusRequest(t *testing.T) {
+	req := StatusRequest{
+		PartnerServiceId: "   12345",
+		CustomerNo:       "123",
+		VirtualAccountNo: "   12345123",
+	}
+	var errorRes *snap_validator_errors.ErrorValidation
+	assert.True(t, errors.As(snap_validator.ValidateModel(req), &errorRes))
+	assert.Equal(t, "4002602", errorRes.SnapCode)
+	assert.Equal(t, "inquiryRequestId", errorRes.Path)
+
+	req.PaymentRequestId = "abc"
+	assert.NoError(t, snap_validator.ValidateModel(req))
+}
+
+func TestCreateRequest(t *testing.T) {
+	req := CreateRequest{
+		PartnerServiceId:      "   12345",
+		CustomerNo:            "123",
+		VirtualAccountNo:      "   12345123",
+		VirtualAccountName:    "Jokul Doe",
+		TrxId:                 "abcdefgh1234",
+		TotalAmount:           snapmodels.Amount{Value: "12345678.00", Currency: "IDR"},
+		VirtualAccountTrxType: TrxTypeClosed,
+		ExpiredDate:           "2030-12-31T23:59:59+07:00",
+	}
+	assert.NoError(t, snap_validator.ValidateModel(req))
+
+	req.VirtualAccountTrxType = "Z"
+	var errorRes *snap_validator_errors.ErrorValidation
+	assert.True(t, errors.As(snap_validator.ValidateModel(req), &errorRes))
+	assert.Equal(t, "4002701", errorRes.SnapCode)
+	assert.Equal(t, "virtualAccountTrxType", errorRes.Path)
+
+	req.VirtualAccountTrxType = TrxTypeClosed
+	req.TotalAmount = snapmodels.Amount{}
+	assert.True(t, errors.As(snap_validator.ValidateModel(req), &errorRes))
+	assert.Equal(t, "4002702", errorRes.SnapCode)
+	assert.Equal(t, "totalAmount", errorRes.Path)
+
+	req.VirtualAccountTrxType = TrxTypeOpen
+	assert.NoError(t, snap_validator.ValidateModel(req))
+}